@@ -0,0 +1,164 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: count, exists and aggregate record(s)
+
+package mccrud
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctypes/operators"
+	"github.com/jackc/pgx/v4"
+)
+
+// computeQueryParams method returns the where-params for the record-ids or query-params (all records, if not specified)
+func (crud *Crud) computeQueryParams() types.QueryParamType {
+	if len(crud.RecordIds) > 0 {
+		return types.QueryParamType{
+			{
+				GroupName: "record_ids",
+				GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"id": {operators.In: crud.RecordIds}}},
+				},
+			},
+		}
+	}
+	return crud.QueryParams
+}
+
+// rowValues function returns the current row values, by the result field-names, in json-value-format
+func rowValues(rows pgx.Rows) (map[string]interface{}, error) {
+	values, err := rows.Values()
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	for i, fd := range rows.FieldDescriptions() {
+		value := values[i]
+		switch val := value.(type) {
+		case [16]byte:
+			// uuid
+			value = fmt.Sprintf("%x-%x-%x-%x-%x", val[0:4], val[4:6], val[6:8], val[8:10], val[10:16])
+		case driver.Valuer:
+			// e.g. numeric values (sum/avg), as string
+			if value, err = val.Value(); err != nil {
+				return nil, err
+			}
+		}
+		result[string(fd.Name)] = value
+	}
+	return jsonRecord(result)
+}
+
+// Count method returns the count (RecordCount) of the record(s) that met the specified record-id(s),
+// query-params or all records
+func (crud *Crud) Count() mcresponse.ResponseMessage {
+	where, err := crud.readQueryParams(crud.computeQueryParams(), nil)
+	if err != nil {
+		return errorResponse(err, "paramsError", "Error computing count-query")
+	}
+	countQuery, err := helper.ComputeCountQuery(crud.TableName, where)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing count-query: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	var count int64
+	if qErr := crud.AppDb.QueryRow(context.Background(), countQuery.SelectQuery, countQuery.FieldValues...).Scan(&count); qErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", qErr.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "record(s) count completed successfully",
+		Value: types.CrudResultType{
+			QueryParam:  crud.QueryParams,
+			RecordIds:   crud.RecordIds,
+			RecordCount: int(count),
+		},
+	})
+}
+
+// Exists method determines if any record met the specified record-id(s), query-params or, if not specified,
+// if the table contains any record. The response Value is true or false.
+func (crud *Crud) Exists() mcresponse.ResponseMessage {
+	where, err := crud.readQueryParams(crud.computeQueryParams(), nil)
+	if err != nil {
+		return errorResponse(err, "paramsError", "Error computing exists-query")
+	}
+	existsQuery, err := helper.ComputeExistsQuery(crud.TableName, where)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing exists-query: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	var exists bool
+	if qErr := crud.AppDb.QueryRow(context.Background(), existsQuery.SelectQuery, existsQuery.FieldValues...).Scan(&exists); qErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", qErr.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("record(s) exists: %v", exists),
+		Value:   exists,
+	})
+}
+
+// Aggregate method computes the aggregate-items (sum, avg, min, max, count, countDistinct), grouped by the GroupBy
+// field-names and filtered by the Having conditions, for the record(s) that met the specified record-id(s),
+// query-params or all records. Each TableRecords' record contains the GroupBy field-names and aggregate aliases' values.
+func (crud *Crud) Aggregate(aggregate types.AggregateParamsType) mcresponse.ResponseMessage {
+	where, err := crud.readQueryParams(crud.computeQueryParams(), nil)
+	if err != nil {
+		return errorResponse(err, "paramsError", "Error computing aggregate-query")
+	}
+	aggregateQuery, err := helper.ComputeAggregateQuery(crud.TableName, aggregate, where)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing aggregate-query: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	rows, qRowErr := crud.AppDb.Query(context.Background(), aggregateQuery.SelectQuery, aggregateQuery.FieldValues...)
+	if qRowErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
+			Value:   nil,
+		})
+	}
+	defer rows.Close()
+	var getResults []interface{}
+	for rows.Next() {
+		rec, recErr := rowValues(rows)
+		if recErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading/getting aggregate records: %v", recErr.Error()),
+				Value:   nil,
+			})
+		}
+		getResults = append(getResults, rec)
+	}
+	if rowErr := rows.Err(); rowErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading/getting aggregate records: %v", rowErr.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "record(s) aggregate completed successfully",
+		Value: types.CrudResultType{
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  len(getResults),
+			TableRecords: getResults,
+		},
+	})
+}
@@ -0,0 +1,181 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: read-through query-result cache and cache invalidation, for the read and write operations
+
+package mccrud
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/abbeymart/mcauditlog"
+	"github.com/abbeymart/mccrud/cache"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctypes/tasks"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"sync"
+	"time"
+)
+
+// cacheGroup suppresses the concurrent (duplicate) reads of the same query, on a cache miss, i.e. cache-stampede protection
+var cacheGroup cache.Group
+
+// cacheGenerations holds the cache invalidation generation, by cache-namespace and table-name, incremented by each
+// (in-process) invalidation, i.e. deleteCache, to skip caching the results read before the invalidation
+var cacheGenerations = struct {
+	sync.Mutex
+	tables map[string]uint64
+}{tables: map[string]uint64{}}
+
+// cacheNamespace function returns the default cache-namespace of the AppDb, i.e. the hash of the database host, port
+// and name, the same for all the processes (pools) of the database
+func cacheNamespace(appDb *pgxpool.Pool) string {
+	if appDb == nil {
+		return ""
+	}
+	connConfig := appDb.Config().ConnConfig
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%v:%v/%v", connConfig.Host, connConfig.Port, connConfig.Database))))[:16]
+}
+
+// cacheGeneration method returns the cache invalidation generation of the table (cacheGenerations)
+func (crud *Crud) cacheGeneration(tableName string) uint64 {
+	cacheGenerations.Lock()
+	defer cacheGenerations.Unlock()
+	return cacheGenerations.tables[crud.CacheNamespace+":"+tableName]
+}
+
+// cacheTTL method returns the cache ttl of the table, i.e. the TableCacheTTL, if specified, or the CacheTTL
+func (crud *Crud) cacheTTL() time.Duration {
+	if ttl, ok := crud.TableCacheTTL[crud.TableName]; ok {
+		return ttl
+	}
+	return crud.CacheTTL
+}
+
+// cacheKeyParamsType is the read-query params of the cache-key
+type cacheKeyParamsType struct {
+	QueryParams      types.QueryParamType
+	RecordIds        []string
+	SortParams       types.SortParamType
+	SortItems        types.SortItemsType
+	ProjectParams    types.ProjectParamType
+	Skip             int
+	Limit            int
+	Cursor           string
+	CursorPaging     bool
+	SoftDelete       bool
+	IncludeDeleted   bool
+	IncludeRelations []string
+	TableFields      []string
+}
+
+// cacheKey method returns the query cache-key of the read (e.g. get-by-id), computed from the current read-query
+// params and the (select) table-fields, within the CacheNamespace, i.e. the database of the records
+func (crud *Crud) cacheKey(readName string, tableFields []string) (string, error) {
+	keyParams, err := json.Marshal(cacheKeyParamsType{
+		QueryParams:      crud.QueryParams,
+		RecordIds:        crud.RecordIds,
+		SortParams:       crud.SortParams,
+		SortItems:        crud.SortItems,
+		ProjectParams:    crud.ProjectParams,
+		Skip:             crud.Skip,
+		Limit:            crud.Limit,
+		Cursor:           crud.Cursor,
+		CursorPaging:     crud.CursorPaging,
+		SoftDelete:       crud.SoftDelete,
+		IncludeDeleted:   crud.IncludeDeleted,
+		IncludeRelations: crud.IncludeRelations,
+		TableFields:      tableFields,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v:%v:%x", crud.CacheNamespace, readName, sha256.Sum256(keyParams)), nil
+}
+
+// deleteCache method invalidates the cached queries of the tables (the crud table, if not specified), and increments
+// their cache generations, i.e. the in-flight reads' results are not cached
+func (crud *Crud) deleteCache(tableNames ...string) {
+	if crud.Cache == nil {
+		return
+	}
+	if len(tableNames) < 1 {
+		tableNames = []string{crud.TableName}
+	}
+	cacheGenerations.Lock()
+	for _, tableName := range tableNames {
+		cacheGenerations.tables[crud.CacheNamespace+":"+tableName]++
+	}
+	cacheGenerations.Unlock()
+	for _, tableName := range tableNames {
+		_ = crud.Cache.DeleteTable(tableName)
+	}
+}
+
+// cacheReadLog method audit-logs the read (logRecords) of the cached or shared (concurrent read) result, for the
+// LogRead option, and returns the logMessage, with the audit-log result
+func (crud *Crud) cacheReadLog(logRecords interface{}, logMessage string) string {
+	if !crud.LogRead {
+		return logMessage
+	}
+	auditInfo := mcauditlog.PgxAuditLogOptionsType{
+		TableName:  crud.TableName,
+		LogRecords: logRecords,
+	}
+	if logRes, logErr := crud.TransLog.AuditLog(tasks.Read, crud.UserInfo.UserId, auditInfo); logErr != nil {
+		return logMessage + fmt.Sprintf(" | Audit-log-error: %v", logErr.Error())
+	} else {
+		return logMessage + fmt.Sprintf(" | Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+	}
+}
+
+// readThrough method returns the cached result of the read (readName) query (tableFields) or, on a cache miss, the
+// getFunc response, caching its (success) result for the cacheTTL, unless the table cache is invalidated (deleteCache)
+// during the read. The concurrent misses of the same query share one getFunc call. The cache hits and the shared
+// results are audit-logged (logRecords), by caller, as the getFunc reads, for the LogRead option.
+func (crud *Crud) readThrough(readName string, tableFields []string, logRecords interface{}, getFunc func() mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	ttl := crud.cacheTTL()
+	if crud.Cache == nil || ttl <= 0 {
+		return getFunc()
+	}
+	key, err := crud.cacheKey(readName, tableFields)
+	if err != nil {
+		return getFunc()
+	}
+	if value, ok, err := crud.Cache.Get(crud.TableName, key); err == nil && ok {
+		var cacheResult types.CrudResultType
+		if jErr := json.Unmarshal(value, &cacheResult); jErr == nil {
+			cacheResult.QueryParam = crud.QueryParams
+			cacheResult.RecordIds = crud.RecordIds
+			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+				Message: crud.cacheReadLog(logRecords, "records successfully retrieved from the cache"),
+				Value:   cacheResult,
+			})
+		}
+	}
+	getRes, _, shared := cacheGroup.Do(crud.CacheNamespace+":"+crud.TableName+":"+key, func() (interface{}, error) {
+		generation := crud.cacheGeneration(crud.TableName)
+		res := getFunc()
+		if res.Code == "success" && crud.cacheGeneration(crud.TableName) == generation {
+			if result, ok := res.Value.(types.CrudResultType); ok {
+				if jByte, jErr := json.Marshal(result); jErr == nil {
+					_ = crud.Cache.Set(crud.TableName, key, jByte, ttl)
+				}
+			}
+		}
+		return res, nil
+	})
+	res, ok := getRes.(mcresponse.ResponseMessage)
+	if !ok {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: "Error reading/getting records: no result",
+			Value:   nil,
+		})
+	}
+	if shared && res.Code == "success" {
+		// the getFunc (in-flight caller) audit-log is not the shared caller's audit-log
+		res.Message = crud.cacheReadLog(logRecords, "records successfully retrieved, shared with a concurrent read")
+	}
+	return res
+}
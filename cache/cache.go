@@ -0,0 +1,20 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: query-result cache interface
+
+package cache
+
+import "time"
+
+// DefaultMaxEntries is the default maximum number of entries of the LRU cache
+const DefaultMaxEntries = 10000
+
+// Cache is the query-result cache, by table-name and (query) key.
+// The values are the json-encoded records. The DeleteTable method invalidates all the table entries,
+// e.g. on create, update or delete.
+type Cache interface {
+	Get(tableName string, key string) ([]byte, bool, error)
+	Set(tableName string, key string, value []byte, ttl time.Duration) error
+	Delete(tableName string, key string) error
+	DeleteTable(tableName string) error
+}
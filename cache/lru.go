@@ -0,0 +1,121 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: in-memory LRU (least-recently-used) cache, with entry expiry (ttl)
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruEntry is the LRU cache entry, i.e. the list-element value
+type lruEntry struct {
+	tableName string
+	key       string
+	value     []byte
+	expireAt  time.Time
+}
+
+// LRUCache is the in-memory cache, evicting the least-recently-used entries beyond the maxEntries
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    *list.List                          // most-recently-used entries first
+	tables     map[string]map[string]*list.Element // entries by table-name and key
+}
+
+// NewLRUCache function returns the LRU cache instance, with the maxEntries (DefaultMaxEntries, if not specified)
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &LRUCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		tables:     map[string]map[string]*list.Element{},
+	}
+}
+
+// Get method returns the (unexpired) value of the table-name and key, and marks the entry as the most-recently-used
+func (c *LRUCache) Get(tableName string, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.tables[tableName][key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expireAt) {
+		c.removeElement(element)
+		return nil, false, nil
+	}
+	c.entries.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set method stores the value of the table-name and key, for the ttl, evicting the least-recently-used entries
+// beyond the maxEntries
+func (c *LRUCache) Set(tableName string, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.tables[tableName][key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expireAt = time.Now().Add(ttl)
+		c.entries.MoveToFront(element)
+		return nil
+	}
+	element := c.entries.PushFront(&lruEntry{
+		tableName: tableName,
+		key:       key,
+		value:     value,
+		expireAt:  time.Now().Add(ttl),
+	})
+	if _, ok := c.tables[tableName]; !ok {
+		c.tables[tableName] = map[string]*list.Element{}
+	}
+	c.tables[tableName][key] = element
+	for c.entries.Len() > c.maxEntries {
+		c.removeElement(c.entries.Back())
+	}
+	return nil
+}
+
+// Delete method removes the entry of the table-name and key
+func (c *LRUCache) Delete(tableName string, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.tables[tableName][key]; ok {
+		c.removeElement(element)
+	}
+	return nil
+}
+
+// DeleteTable method removes all the entries of the table-name
+func (c *LRUCache) DeleteTable(tableName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, element := range c.tables[tableName] {
+		c.entries.Remove(element)
+	}
+	delete(c.tables, tableName)
+	return nil
+}
+
+// Len method returns the number of entries, including the expired entries not yet removed
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// removeElement method removes the list-element and its table-entry, the caller holds the lock
+func (c *LRUCache) removeElement(element *list.Element) {
+	entry := c.entries.Remove(element).(*lruEntry)
+	delete(c.tables[entry.tableName], entry.key)
+	if len(c.tables[entry.tableName]) < 1 {
+		delete(c.tables, entry.tableName)
+	}
+}
@@ -0,0 +1,111 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: in-memory LRU cache test cases
+
+package cache
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	type cacheEntryType struct {
+		tableName string
+		key       string
+	}
+	testCases := []struct {
+		name       string
+		maxEntries int
+		task       func(c *LRUCache)
+		hits       []cacheEntryType
+		misses     []cacheEntryType
+		count      int
+	}{
+		{
+			name:       "should evict the least-recently-used entry, beyond the max-entries:",
+			maxEntries: 2,
+			task: func(c *LRUCache) {
+				_ = c.Set("users", "a", []byte("a"), time.Minute)
+				_ = c.Set("users", "b", []byte("b"), time.Minute)
+				// a is the most-recently-used entry
+				_, _, _ = c.Get("users", "a")
+				_ = c.Set("roles", "c", []byte("c"), time.Minute)
+			},
+			hits:   []cacheEntryType{{"users", "a"}, {"roles", "c"}},
+			misses: []cacheEntryType{{"users", "b"}},
+			count:  2,
+		},
+		{
+			name:       "should update the existing entry, without eviction:",
+			maxEntries: 2,
+			task: func(c *LRUCache) {
+				_ = c.Set("users", "a", []byte("a"), time.Minute)
+				_ = c.Set("users", "b", []byte("b"), time.Minute)
+				_ = c.Set("users", "a", []byte("a2"), time.Minute)
+			},
+			hits:  []cacheEntryType{{"users", "a"}, {"users", "b"}},
+			count: 2,
+		},
+		{
+			name:       "should remove the expired entry, on get:",
+			maxEntries: 10,
+			task: func(c *LRUCache) {
+				_ = c.Set("users", "a", []byte("a"), time.Minute)
+				_ = c.Set("users", "b", []byte("b"), -time.Second)
+				_, _, _ = c.Get("users", "b")
+			},
+			hits:   []cacheEntryType{{"users", "a"}},
+			misses: []cacheEntryType{{"users", "b"}},
+			count:  1,
+		},
+		{
+			name:       "should delete the entry and the table entries:",
+			maxEntries: 10,
+			task: func(c *LRUCache) {
+				_ = c.Set("users", "a", []byte("a"), time.Minute)
+				_ = c.Set("users", "b", []byte("b"), time.Minute)
+				_ = c.Set("users", "c", []byte("c"), time.Minute)
+				_ = c.Set("roles", "a", []byte("a"), time.Minute)
+				_ = c.Delete("users", "c")
+				_ = c.DeleteTable("users")
+			},
+			hits:   []cacheEntryType{{"roles", "a"}},
+			misses: []cacheEntryType{{"users", "a"}, {"users", "b"}, {"users", "c"}},
+			count:  1,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				c := NewLRUCache(tc.maxEntries)
+				tc.task(c)
+				mctest.AssertEquals(t, c.Len(), tc.count, "cache entries count should be as expected")
+				for _, entry := range tc.hits {
+					_, ok, err := c.Get(entry.tableName, entry.key)
+					mctest.AssertEquals(t, err, nil, "cache get error should be nil")
+					mctest.AssertEquals(t, ok, true, "cache entry "+entry.tableName+":"+entry.key+" should be cached")
+				}
+				for _, entry := range tc.misses {
+					_, ok, _ := c.Get(entry.tableName, entry.key)
+					mctest.AssertEquals(t, ok, false, "cache entry "+entry.tableName+":"+entry.key+" should not be cached")
+				}
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the latest value and default max-entries:",
+		TestFunc: func() {
+			c := NewLRUCache(0)
+			mctest.AssertEquals(t, c.maxEntries, DefaultMaxEntries, "cache max-entries should be the default")
+			_ = c.Set("users", "a", []byte("a"), time.Minute)
+			_ = c.Set("users", "a", []byte("a2"), time.Minute)
+			value, _, _ := c.Get("users", "a")
+			mctest.AssertEquals(t, string(value), "a2", "cache value should be the latest value")
+		},
+	})
+	mctest.PostTestResult()
+}
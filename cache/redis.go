@@ -0,0 +1,259 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: Redis-protocol (RESP) cache, e.g. Redis, KeyDB or Valkey, shared by the application instances
+
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// RedisOptionsType is the Redis cache options
+type RedisOptionsType struct {
+	Addr      string        // host:port, default: localhost:6379
+	Password  string        // AUTH password, if specified
+	DB        int           // SELECT db-number, if specified
+	KeyPrefix string        // keys prefix, default: mccrud
+	PoolSize  int           // maximum idle connections, default: 10
+	Timeout   time.Duration // dial, read and write timeout, default: 5 seconds
+}
+
+// redisConn is the Redis connection, with the buffered reader and writer
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// RedisCache is the Redis-protocol cache: the entries are stored as prefix:table:key strings (with the ttl), and
+// the table keys in the prefix:table set, for the table invalidation (DeleteTable)
+type RedisCache struct {
+	options RedisOptionsType
+	pool    chan *redisConn
+}
+
+// NewRedisCache function returns the Redis cache instance. The connections are dialed on demand.
+func NewRedisCache(options RedisOptionsType) *RedisCache {
+	if options.Addr == "" {
+		options.Addr = "localhost:6379"
+	}
+	if options.KeyPrefix == "" {
+		options.KeyPrefix = "mccrud"
+	}
+	if options.PoolSize <= 0 {
+		options.PoolSize = 10
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 5 * time.Second
+	}
+	return &RedisCache{
+		options: options,
+		pool:    make(chan *redisConn, options.PoolSize),
+	}
+}
+
+// entryKey method returns the Redis key of the table-name and key
+func (c *RedisCache) entryKey(tableName string, key string) string {
+	return fmt.Sprintf("%v:%v:%v", c.options.KeyPrefix, tableName, key)
+}
+
+// tableKey method returns the Redis key of the table (keys) set
+func (c *RedisCache) tableKey(tableName string) string {
+	return fmt.Sprintf("%v:%v", c.options.KeyPrefix, tableName)
+}
+
+// Get method returns the value of the table-name and key
+func (c *RedisCache) Get(tableName string, key string) ([]byte, bool, error) {
+	replies, err := c.do([]string{"GET", c.entryKey(tableName, key)})
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := replies[0].([]byte)
+	return value, ok, nil
+}
+
+// Set method stores the value of the table-name and key, for the ttl, and adds the key to the table set
+func (c *RedisCache) Set(tableName string, key string, value []byte, ttl time.Duration) error {
+	entryKey := c.entryKey(tableName, key)
+	ttlMs := strconv.FormatInt(ttl.Milliseconds(), 10)
+	_, err := c.do(
+		[]string{"SET", entryKey, string(value), "PX", ttlMs},
+		[]string{"SADD", c.tableKey(tableName), entryKey},
+		[]string{"PEXPIRE", c.tableKey(tableName), ttlMs},
+	)
+	return err
+}
+
+// Delete method removes the entry of the table-name and key
+func (c *RedisCache) Delete(tableName string, key string) error {
+	entryKey := c.entryKey(tableName, key)
+	_, err := c.do([]string{"DEL", entryKey}, []string{"SREM", c.tableKey(tableName), entryKey})
+	return err
+}
+
+// DeleteTable method removes all the entries of the table-name, i.e. the keys of the table set
+func (c *RedisCache) DeleteTable(tableName string) error {
+	replies, err := c.do([]string{"SMEMBERS", c.tableKey(tableName)})
+	if err != nil {
+		return err
+	}
+	delCommand := []string{"DEL", c.tableKey(tableName)}
+	if members, ok := replies[0].([]interface{}); ok {
+		for _, member := range members {
+			if memberKey, ok := member.([]byte); ok {
+				delCommand = append(delCommand, string(memberKey))
+			}
+		}
+	}
+	_, err = c.do(delCommand)
+	return err
+}
+
+// do method sends the (pipelined) commands and returns their replies. The connection is discarded on error,
+// and returned to the pool otherwise.
+func (c *RedisCache) do(commands ...[]string) ([]interface{}, error) {
+	rc, err := c.getConn()
+	if err != nil {
+		return nil, err
+	}
+	replies, err := rc.pipeline(c.options.Timeout, commands...)
+	if err != nil {
+		_ = rc.conn.Close()
+		return nil, err
+	}
+	c.putConn(rc)
+	return replies, nil
+}
+
+// getConn method returns an idle (pooled) connection or dials a new connection (AUTH and SELECT, if specified)
+func (c *RedisCache) getConn() (*redisConn, error) {
+	select {
+	case rc := <-c.pool:
+		return rc, nil
+	default:
+	}
+	conn, err := net.DialTimeout("tcp", c.options.Addr, c.options.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	rc := &redisConn{conn: conn, reader: bufio.NewReader(conn), writer: bufio.NewWriter(conn)}
+	var setupCommands [][]string
+	if c.options.Password != "" {
+		setupCommands = append(setupCommands, []string{"AUTH", c.options.Password})
+	}
+	if c.options.DB > 0 {
+		setupCommands = append(setupCommands, []string{"SELECT", strconv.Itoa(c.options.DB)})
+	}
+	if len(setupCommands) > 0 {
+		if _, err = rc.pipeline(c.options.Timeout, setupCommands...); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return rc, nil
+}
+
+// putConn method returns the connection to the pool, or closes it, if the pool is full
+func (c *RedisCache) putConn(rc *redisConn) {
+	select {
+	case c.pool <- rc:
+	default:
+		_ = rc.conn.Close()
+	}
+}
+
+// pipeline method writes the commands (RESP arrays of bulk-strings) and reads a reply per command.
+// The error replies are returned as errors.
+func (rc *redisConn) pipeline(timeout time.Duration, commands ...[]string) ([]interface{}, error) {
+	if err := rc.conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	for _, command := range commands {
+		if _, err := fmt.Fprintf(rc.writer, "*%d\r\n", len(command)); err != nil {
+			return nil, err
+		}
+		for _, arg := range command {
+			if _, err := fmt.Fprintf(rc.writer, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := rc.writer.Flush(); err != nil {
+		return nil, err
+	}
+	replies := make([]interface{}, len(commands))
+	var replyErr error
+	for i := range commands {
+		reply, err := rc.readReply()
+		if err != nil {
+			if _, ok := err.(redisError); !ok {
+				return nil, err
+			}
+			// read the remaining replies, i.e. keep the connection in sync
+			replyErr = err
+		}
+		replies[i] = reply
+	}
+	if replyErr != nil {
+		return nil, replyErr
+	}
+	return replies, nil
+}
+
+// redisError is the Redis error reply
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// readReply method reads a RESP reply: simple-string (string), error (redisError), integer (int64),
+// bulk-string ([]byte, or nil) and array ([]interface{}, or nil)
+func (rc *redisConn) readReply() (interface{}, error) {
+	line, err := rc.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New(fmt.Sprintf("redis: invalid reply [%q]", line))
+	}
+	line = line[:len(line)-2]
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		value := make([]byte, size+2)
+		if _, err = io.ReadFull(rc.reader, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = rc.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("redis: invalid reply [%q]", line))
+	}
+}
@@ -0,0 +1,85 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: Redis-protocol (RESP) reply parser and pipeline test cases
+
+package cache
+
+import (
+	"bufio"
+	"github.com/abbeymart/mctest"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedisReply(t *testing.T) {
+	testCases := []struct {
+		name    string
+		reply   string
+		value   interface{}
+		isError bool
+	}{
+		{name: "should read the simple-string reply:", reply: "+OK\r\n", value: "OK"},
+		{name: "should read the integer reply:", reply: ":42\r\n", value: int64(42)},
+		{name: "should read the bulk-string reply:", reply: "$6\r\nab\r\ncd\r\n", value: []byte("ab\r\ncd")},
+		{name: "should read the null bulk-string reply:", reply: "$-1\r\n", value: nil},
+		{name: "should read the array reply, with the nested replies:", reply: "*3\r\n$1\r\na\r\n:1\r\n*1\r\n+b\r\n",
+			value: []interface{}{[]byte("a"), int64(1), []interface{}{"b"}}},
+		{name: "should read the null array reply:", reply: "*-1\r\n", value: nil},
+		{name: "should return the error reply as error:", reply: "-ERR unknown command\r\n", isError: true},
+		{name: "should return an error for the unknown reply type:", reply: "?1\r\n", isError: true},
+		{name: "should return an error for the reply without the CRLF:", reply: "+OK\n", isError: true},
+		{name: "should return an error for the truncated bulk-string:", reply: "$5\r\nab", isError: true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				rc := &redisConn{reader: bufio.NewReader(strings.NewReader(tc.reply))}
+				value, err := rc.readReply()
+				mctest.AssertEquals(t, err != nil, tc.isError, "reply error should be as expected")
+				mctest.AssertStrictEquals(t, value, tc.value, "reply value should be as expected")
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should pipeline the commands and keep the connection in sync on the error reply:",
+		TestFunc: func() {
+			client, server := net.Pipe()
+			defer client.Close()
+			defer server.Close()
+			rc := &redisConn{conn: client, reader: bufio.NewReader(client), writer: bufio.NewWriter(client)}
+			requests := make(chan string, 2)
+			go func() {
+				// 1st pipeline: GET and SREM (error reply), 2nd pipeline: DEL
+				for _, request := range []struct {
+					size    int
+					replies string
+				}{
+					{size: len("*2\r\n$3\r\nGET\r\n$3\r\nk:a\r\n*3\r\n$4\r\nSREM\r\n$1\r\nk\r\n$3\r\nk:a\r\n"), replies: "$1\r\na\r\n-WRONGTYPE wrong kind of value\r\n"},
+					{size: len("*2\r\n$3\r\nDEL\r\n$3\r\nk:a\r\n"), replies: ":1\r\n"},
+				} {
+					buf := make([]byte, request.size)
+					if _, err := io.ReadFull(server, buf); err != nil {
+						return
+					}
+					requests <- string(buf)
+					if _, err := server.Write([]byte(request.replies)); err != nil {
+						return
+					}
+				}
+			}()
+			_, err := rc.pipeline(time.Second, []string{"GET", "k:a"}, []string{"SREM", "k", "k:a"})
+			mctest.AssertEquals(t, <-requests, "*2\r\n$3\r\nGET\r\n$3\r\nk:a\r\n*3\r\n$4\r\nSREM\r\n$1\r\nk\r\n$3\r\nk:a\r\n", "pipeline commands should be RESP arrays of bulk-strings")
+			mctest.AssertEquals(t, err, error(redisError("WRONGTYPE wrong kind of value")), "pipeline error reply should be returned")
+			replies, err := rc.pipeline(time.Second, []string{"DEL", "k:a"})
+			mctest.AssertEquals(t, <-requests, "*2\r\n$3\r\nDEL\r\n$3\r\nk:a\r\n", "pipeline command should be as expected")
+			mctest.AssertEquals(t, err, nil, "pipeline error should be nil")
+			mctest.AssertStrictEquals(t, replies, []interface{}{int64(1)}, "pipeline replies should be in sync")
+		},
+	})
+	mctest.PostTestResult()
+}
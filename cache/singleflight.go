@@ -0,0 +1,48 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: singleflight (duplicate call suppression), i.e. cache-stampede protection for the read-through cache
+
+package cache
+
+import "sync"
+
+// flightCall is the in-flight (or completed) call of the Group
+type flightCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+// Group suppresses the duplicate calls, by key: the concurrent callers of the same key wait for, and share
+// the result of, the first (in-flight) call. The zero value is ready to use.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// Do method executes the fn, unless a call of the same key is in-flight, and returns its result and whether
+// the result is shared, i.e. returned by the in-flight call
+func (g *Group) Do(key string, fn func() (interface{}, error)) (interface{}, error, bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err, true
+	}
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+	call.value, call.err = fn()
+	return call.value, call.err, false
+}
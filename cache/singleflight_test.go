@@ -0,0 +1,82 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: singleflight (duplicate call suppression) test cases
+
+package cache
+
+import (
+	"errors"
+	"github.com/abbeymart/mctest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should share the in-flight call result with the concurrent callers of the same key:",
+		TestFunc: func() {
+			var g Group
+			var calls int32
+			started := make(chan struct{})
+			release := make(chan struct{})
+			fn := func() (interface{}, error) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					close(started)
+				}
+				<-release
+				return "records", nil
+			}
+			const callers = 5
+			values := make([]interface{}, callers)
+			shares := make([]bool, callers)
+			var wg sync.WaitGroup
+			wg.Add(callers)
+			go func() {
+				defer wg.Done()
+				values[0], _, shares[0] = g.Do("users:key", fn)
+			}()
+			<-started
+			for i := 1; i < callers; i++ {
+				go func(i int) {
+					defer wg.Done()
+					values[i], _, shares[i] = g.Do("users:key", fn)
+				}(i)
+			}
+			// the concurrent callers wait for the in-flight call
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			mctest.AssertEquals(t, atomic.LoadInt32(&calls), int32(1), "fn should be called once")
+			sharedCount := 0
+			for i := 0; i < callers; i++ {
+				mctest.AssertEquals(t, values[i], "records", "caller value should be the in-flight call value")
+				if shares[i] {
+					sharedCount++
+				}
+			}
+			mctest.AssertEquals(t, shares[0], false, "in-flight caller result should not be shared")
+			mctest.AssertEquals(t, sharedCount, callers-1, "concurrent callers results should be shared")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should call the fn for the completed call and the different keys:",
+		TestFunc: func() {
+			var g Group
+			calls := 0
+			fn := func() (interface{}, error) {
+				calls++
+				return nil, errors.New("read error")
+			}
+			_, err, shared := g.Do("users:key", fn)
+			mctest.AssertEquals(t, err.Error(), "read error", "call error should be returned")
+			mctest.AssertEquals(t, shared, false, "call result should not be shared")
+			_, _, _ = g.Do("users:key", fn)
+			_, _, _ = g.Do("roles:key", fn)
+			mctest.AssertEquals(t, calls, 3, "fn should be called for each completed call and key")
+			mctest.AssertEquals(t, len(g.calls), 0, "completed calls should be removed")
+		},
+	})
+	mctest.PostTestResult()
+}
@@ -0,0 +1,73 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: read-through cache, cache-key namespace and invalidation test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mccrud/cache"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestReadThrough(t *testing.T) {
+	newCacheCrud := func(namespace string, lruCache *cache.LRUCache) *Crud {
+		crud := &Crud{}
+		crud.TableName = "users"
+		crud.RecordIds = []string{"id-1"}
+		crud.Cache = lruCache
+		crud.CacheTTL = time.Minute
+		crud.CacheNamespace = namespace
+		return crud
+	}
+	getResult := func() mcresponse.ResponseMessage {
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Value: types.CrudResultType{RecordCount: 1},
+		})
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the cache-key within the cache-namespace:",
+		TestFunc: func() {
+			key1, _ := newCacheCrud("db-1", nil).cacheKey("get-by-id", []string{"id"})
+			key2, _ := newCacheCrud("db-2", nil).cacheKey("get-by-id", []string{"id"})
+			mctest.AssertEquals(t, key1[:len("db-1:get-by-id:")], "db-1:get-by-id:", "cache-key should start with the namespace")
+			mctest.AssertEquals(t, key1 != key2, true, "cache-keys of the namespaces should differ")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should not cache the result read during the table cache invalidation:",
+		TestFunc: func() {
+			lruCache := cache.NewLRUCache(10)
+			crud := newCacheCrud("db-stale", lruCache)
+			res := crud.readThrough("get-by-id", []string{"id"}, crud.RecordIds, func() mcresponse.ResponseMessage {
+				// a concurrent write invalidates the table cache, during the read
+				crud.deleteCache()
+				return getResult()
+			})
+			mctest.AssertEquals(t, res.Code, "success", "read-through should return code: success")
+			mctest.AssertEquals(t, lruCache.Len(), 0, "read-through result should not be cached")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cache the result and return the cached result, without the read:",
+		TestFunc: func() {
+			lruCache := cache.NewLRUCache(10)
+			crud := newCacheCrud("db-hit", lruCache)
+			_ = crud.readThrough("get-by-id", []string{"id"}, crud.RecordIds, getResult)
+			mctest.AssertEquals(t, lruCache.Len(), 1, "read-through result should be cached")
+			reads := 0
+			res := crud.readThrough("get-by-id", []string{"id"}, crud.RecordIds, func() mcresponse.ResponseMessage {
+				reads++
+				return getResult()
+			})
+			mctest.AssertEquals(t, res.Code, "success", "read-through should return code: success")
+			mctest.AssertEquals(t, reads, 0, "cached result should be returned, without the read")
+			mctest.AssertEquals(t, res.Value.(types.CrudResultType).RecordCount, 1, "cached result should be as expected")
+		},
+	})
+	mctest.PostTestResult()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mcresponse"
 	"github.com/abbeymart/mctypes/tasks"
+	"time"
 )
 
 // Crud object / struct
@@ -33,15 +34,37 @@ func NewCrud(params types.CrudParamsType, options types.CrudOptionsType) (crudIn
 	crudInstance.RecordIds = params.RecordIds
 	crudInstance.QueryParams = params.QueryParams
 	crudInstance.SortParams = params.SortParams
+	crudInstance.SortItems = params.SortItems
 	crudInstance.ProjectParams = params.ProjectParams
 	crudInstance.ExistParams = params.ExistParams
 	crudInstance.Token = params.Token
 	crudInstance.TaskName = params.TaskName
 	crudInstance.Skip = params.Skip
 	crudInstance.Limit = params.Limit
+	crudInstance.Cursor = params.Cursor
+	crudInstance.IncludeRelations = params.IncludeRelations
 
 	// crud options
 	crudInstance.MaxQueryLimit = options.MaxQueryLimit
+	crudInstance.CursorPaging = options.CursorPaging
+	crudInstance.FetchSize = options.FetchSize
+	crudInstance.BatchSize = options.BatchSize
+	crudInstance.Model = options.Model
+	crudInstance.Upsert = options.Upsert
+	crudInstance.ConflictFields = options.ConflictFields
+	crudInstance.UpsertFields = options.UpsertFields
+	crudInstance.UpsertDoNothing = options.UpsertDoNothing
+	crudInstance.VersionField = options.VersionField
+	crudInstance.ReturnRecords = options.ReturnRecords
+	crudInstance.SoftDelete = options.SoftDelete
+	crudInstance.IncludeDeleted = options.IncludeDeleted
+	crudInstance.DeleteAllMaxCount = options.DeleteAllMaxCount
+	crudInstance.DeleteAllBackupTable = options.DeleteAllBackupTable
+	crudInstance.ValidateModel = options.ValidateModel
+	crudInstance.ParentTables = options.ParentTables
+	crudInstance.ChildTables = options.ChildTables
+	crudInstance.RecursiveDelete = options.RecursiveDelete
+	crudInstance.RecExistMessage = options.RecExistMessage
 	crudInstance.AuditTable = options.AuditTable
 	crudInstance.AccessTable = options.AccessTable
 	crudInstance.RoleTable = options.RoleTable
@@ -56,13 +79,21 @@ func NewCrud(params types.CrudParamsType, options types.CrudOptionsType) (crudIn
 	crudInstance.LogUpdate = options.LogUpdate
 	crudInstance.LogDelete = options.LogDelete
 	crudInstance.CheckAccess = options.CheckAccess // Dec 09/2020: user to implement auth as a middleware
-	crudInstance.CacheExpire = options.CacheExpire // cache expire in secs
-	// Compute HashKey from TableName, QueryParams, SortParams, ProjectParams and RecordIds
+	crudInstance.Cache = options.Cache
+	crudInstance.CacheTTL = options.CacheTTL
+	crudInstance.TableCacheTTL = options.TableCacheTTL
+	crudInstance.CacheNamespace = options.CacheNamespace
+	// Compute HashKey from TableName, QueryParams, SortParams, SortItems, ProjectParams, RecordIds, Skip, Limit, Cursor,
+	// IncludeRelations and IncludeDeleted (soft-deleted records)
 	qParam, _ := json.Marshal(params.QueryParams)
 	sParam, _ := json.Marshal(params.SortParams)
+	sItems, _ := json.Marshal(params.SortItems)
 	pParam, _ := json.Marshal(params.ProjectParams)
 	dIds, _ := json.Marshal(params.RecordIds)
-	crudInstance.HashKey = params.TableName + string(qParam) + string(sParam) + string(pParam) + string(dIds)
+	iRels, _ := json.Marshal(params.IncludeRelations)
+	crudInstance.HashKey = params.TableName + string(qParam) + string(sParam) + string(sItems) + string(pParam) + string(dIds) +
+		fmt.Sprintf("%v-%v", params.Skip, params.Limit) + params.Cursor + string(iRels) +
+		fmt.Sprintf("%v-%v", options.SoftDelete, options.IncludeDeleted)
 
 	// Default values
 	if crudInstance.AuditTable == "" {
@@ -101,8 +132,28 @@ func NewCrud(params types.CrudParamsType, options types.CrudOptionsType) (crudIn
 		crudInstance.Limit = crudInstance.MaxQueryLimit
 	}
 
-	if crudInstance.CacheExpire <= 0 {
-		crudInstance.CacheExpire = 300 // 300 secs, 5 minutes
+	if crudInstance.BatchSize <= 0 {
+		crudInstance.BatchSize = defaultBatchSize
+	}
+
+	if crudInstance.DeleteAllMaxCount <= 0 {
+		crudInstance.DeleteAllMaxCount = defaultDeleteAllMaxCount
+	}
+
+	if crudInstance.RecExistMessage == "" {
+		crudInstance.RecExistMessage = "Save / update error: record(s) exist(s) for the specified unique field(s)"
+	}
+
+	if crudInstance.FetchSize <= 0 {
+		crudInstance.FetchSize = defaultFetchSize
+	}
+
+	if crudInstance.CacheTTL == 0 {
+		crudInstance.CacheTTL = 5 * time.Minute
+	}
+
+	if crudInstance.Cache != nil && crudInstance.CacheNamespace == "" {
+		crudInstance.CacheNamespace = cacheNamespace(crudInstance.AppDb)
 	}
 
 	// Audit/TransLog instance
@@ -120,6 +171,19 @@ func (crud Crud) String() string {
 
 // SaveRecord function creates new record(s) or updates existing record(s)
 func (crud *Crud) SaveRecord(params types.SaveCrudParamsType) mcresponse.ResponseMessage {
+	// upsert: insert or update (on conflict) the records, new and existing records may be mixed
+	if crud.Upsert {
+		// check task-permission - create and update
+		if crud.CheckAccess {
+			for _, taskType := range []string{tasks.Create, tasks.Update} {
+				accessRes := crud.TaskPermission(taskType)
+				if accessRes.Code != "success" {
+					return accessRes
+				}
+			}
+		}
+		return crud.UpsertRecords(crud.ActionParams, params.CreateTableFields)
+	}
 	//  compute taskType-records from actionParams: create or update
 	var (
 		createRecs types.ActionParamsType // records without id field-value
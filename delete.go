@@ -8,15 +8,23 @@ import (
 	"context"
 	"fmt"
 	"github.com/abbeymart/mcauditlog"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mccrud/helper"
 	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mcresponse"
 	"github.com/abbeymart/mctypes/tasks"
+	"strings"
 )
 
-// DeleteById method deletes or removes record(s) by record-id(s)
+// defaultDeleteAllMaxCount is the default maximum number of records permitted to be deleted by DeleteAll
+const defaultDeleteAllMaxCount = 10000
+
+// DeleteById method deletes or removes record(s) by record-id(s), or soft-deletes, for the SoftDelete option
 func (crud *Crud) DeleteById() mcresponse.ResponseMessage {
+	return crud.deleteById(nil)
+}
+
+// deleteById method deletes the record(s) by record-id(s), with the snapshot of the snapshotFields, see deleteRecords
+func (crud *Crud) deleteById(snapshotFields []string) mcresponse.ResponseMessage {
 	// compute delete query by record-ids
 	deleteQuery, dQErr := helper.ComputeDeleteQueryById(crud.TableName, crud.RecordIds)
 	if dQErr != nil {
@@ -25,74 +33,264 @@ func (crud *Crud) DeleteById() mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
-	commandTag, delErr := crud.AppDb.Exec(context.Background(), deleteQuery)
-	if delErr != nil {
+	return crud.deleteRecords(deleteQuery, snapshotFields)
+}
+
+// DeleteByParam method deletes or removes record(s) by query-parameters or where conditions, or soft-deletes,
+// for the SoftDelete option
+func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
+	return crud.deleteByParam(nil)
+}
+
+// deleteByParam method deletes the record(s) by query-params, with the snapshot of the snapshotFields, see deleteRecords
+func (crud *Crud) deleteByParam(snapshotFields []string) mcresponse.ResponseMessage {
+	// compute delete query by query-params
+	deleteQuery, dQErr := helper.ComputeDeleteQueryByParam(crud.TableName, crud.QueryParams)
+	if dQErr != nil {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
+			Message: fmt.Sprintf("Error computing delete-query: %v", dQErr.Error()),
 			Value:   nil,
 		})
 	}
-
-	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: "Record(s) deleted successfully",
-		Value:   commandTag.Delete(),
-	})
+	return crud.deleteRecords(deleteQuery, snapshotFields)
 }
 
-// DeleteByParam method deletes or removes record(s) by query-parameters or where conditions
-func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
-	// compute delete query by query-params
-	deleteQuery, dQErr := helper.ComputeDeleteQueryByParam(crud.TableName, crud.QueryParams)
+// deleteRecords method deletes the records met by the delete-query (by record-ids or query-params) or, for the
+// SoftDelete option, sets their deleted_at/deleted_by fields, in a transaction. For the ChildTables option, the
+// subItems response is returned, if any of the records is referenced by the child-tables' records or, for the
+// RecursiveDelete option (not for the soft-delete, i.e. the references are retained), the sub-items are deleted
+// first, and audit-logged, by child-table, for the LogDelete option. The snapshotFields of the records to delete,
+// if specified, are read (locked) in the transaction, as the CurrentRecords, i.e. for the delete audit-log.
+func (crud *Crud) deleteRecords(deleteQuery types.DeleteQueryResponseType, snapshotFields []string) mcresponse.ResponseMessage {
+	execQuery := deleteQuery
+	var dQErr error
+	if crud.SoftDelete {
+		// soft-delete: set the deleted_at/deleted_by fields
+		execQuery, dQErr = helper.ComputeSoftDeleteQuery(crud.TableName, deleteQuery, crud.UserInfo.UserId)
+	}
+	returningQuery := ""
+	if dQErr == nil {
+		returningQuery, dQErr = crud.returningQuery()
+	}
+	snapshotQuery := types.SelectQueryResponseType{}
+	if dQErr == nil && len(snapshotFields) > 0 {
+		snapshotQuery, dQErr = helper.ComputeDeleteSnapshotQuery(crud.TableName, deleteQuery, snapshotFields, crud.SoftDelete)
+	}
 	if dQErr != nil {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error computing delete-query: %v", dQErr.Error()),
 			Value:   nil,
 		})
 	}
-	commandTag, delErr := crud.AppDb.Exec(context.Background(), deleteQuery)
+	tx, txRes, ok := crud.beginWrite(nil, snapshotFields, "deleteError", "Error deleting record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// records to delete (snapshot), for the audit-log
+	if snapshotQuery.SelectQuery != "" {
+		var snapshotRecords []interface{}
+		rows, sErr := tx.Query(context.Background(), snapshotQuery.SelectQuery, snapshotQuery.FieldValues...)
+		if sErr == nil {
+			_, snapshotRecords, sErr = scanReturning(rows, true)
+		}
+		if sErr != nil {
+			return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading the record(s) to delete: %v", sErr.Error()),
+				Value:   nil,
+			})
+		}
+		crud.CurrentRecords = snapshotRecords
+	}
+	// child-tables' references (sub-items) check or, except for the soft-delete, recursive delete
+	var deletedItems []deletedSubItems
+	if len(crud.ChildTables) > 0 && crud.RecursiveDelete && !crud.SoftDelete {
+		var subErr error
+		deletedItems, subErr = crud.deleteSubItems(tx, crud.TableName, deleteQuery.WhereQuery, deleteQuery.FieldValues, crud.ChildTables, []string{crud.TableName})
+		if subErr != nil {
+			return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error deleting sub-items: %v", subErr.Error()),
+				Value:   nil,
+			})
+		}
+	} else if len(crud.ChildTables) > 0 {
+		subItems, err := crud.checkSubItems(tx, deleteQuery)
+		if err != nil {
+			return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error checking sub-items: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		if len(subItems) > 0 {
+			var subItemsDesc []string
+			for _, subItem := range subItems {
+				subItemsDesc = append(subItemsDesc, fmt.Sprintf("%v(%v)", subItem.TableName, subItem.FieldName))
+			}
+			return mcresponse.GetResMessage("subItems", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Record(s) include sub-items, which must be removed first: %v", strings.Join(subItemsDesc, ", ")),
+				Value:   subItems,
+			})
+		}
+	}
+	deleteCount, deleteRecords, delErr := execReturning(tx, execQuery.DeleteQuery, execQuery.FieldValues, returningQuery)
 	if delErr != nil {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
 			Value:   nil,
 		})
 	}
+	if txcErr := tx.Commit(context.Background()); txcErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
 
-	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	// delete cache, for the table and the child-tables
+	crud.deleteCache()
+	var subItemsDesc []string
+	for _, deletedItem := range deletedItems {
+		crud.deleteCache(deletedItem.tableName)
+		subItemsDesc = append(subItemsDesc, fmt.Sprintf("%v(%v)", deletedItem.tableName, deletedItem.recordCount))
+	}
+
+	message := "Record(s) deleted successfully"
+	if len(subItemsDesc) > 0 {
+		message += " | Sub-items deleted: " + strings.Join(subItemsDesc, ", ")
+	}
+	// perform audit-log, for the sub-items
+	if crud.LogDelete {
+		for _, deletedItem := range deletedItems {
+			auditInfo := mcauditlog.PgxAuditLogOptionsType{
+				TableName:  deletedItem.tableName,
+				LogRecords: deletedItem.records,
+			}
+			if _, logErr := crud.TransLog.AuditLog(tasks.Delete, crud.UserInfo.UserId, auditInfo); logErr != nil {
+				message += fmt.Sprintf(" | Audit-log-error [%v]: %v", deletedItem.tableName, logErr.Error())
+			}
+		}
+	}
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: "Record(s) deleted successfully",
-		Value:   commandTag.Delete(),
+		Message: message,
+		Value: types.CrudResultType{
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  deleteCount,
+			TableRecords: deleteRecords,
+		},
 	})
 }
 
-// DeleteAll method deletes or removes all records in the tables. Recommended for admin-users only
+// DeleteAll method deletes or removes all records in the table, for admin-users only, with the confirmToken that
+// matches the table-name, and up to the DeleteAllMaxCount records. The records are archived to the
+// DeleteAllBackupTable (by column-name), if specified, in the same transaction. For the SoftDelete option, the
+// not-deleted records are soft-deleted (set deleted_at/deleted_by), i.e. retained, not archived, see Purge.
+// The response Value includes the deleted records' count.
 // Use if and only if you know what you are doing
-func (crud *Crud) DeleteAll() mcresponse.ResponseMessage {
+func (crud *Crud) DeleteAll(confirmToken string) mcresponse.ResponseMessage {
 	// ***** perform DELETE-ALL-RECORDS FROM A TABLE, IF RELATIONS/CONSTRAINTS PERMIT *****
 	// ***** && IF-AND-ONLY-IF-YOU-KNOW-WHAT-YOU-ARE-DOING *****
-	// compute delete query
-	delQuery := fmt.Sprintf("DELETE FROM %v", crud.TableName)
-	commandTag, delErr := crud.AppDb.Exec(context.Background(), delQuery)
+	if confirmToken == "" || confirmToken != crud.TableName {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Delete-all error: the confirm-token must match the table-name",
+			Value:   nil,
+		})
+	}
+	// check admin access
+	if accessRes, ok := crud.checkAdminAccess("delete-all"); !ok {
+		return accessRes
+	}
+	tx, txRes, ok := crud.beginWrite(nil, nil, "deleteError", "Error deleting record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// compute delete query, or the soft-delete (not-deleted records) query
+	delQuery := types.DeleteQueryResponseType{DeleteQuery: fmt.Sprintf("DELETE FROM %v", crud.TableName)}
+	if crud.SoftDelete {
+		var dQErr error
+		if delQuery, dQErr = helper.ComputeSoftDeleteAllQuery(crud.TableName, crud.UserInfo.UserId); dQErr != nil {
+			return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error computing delete-query: %v", dQErr.Error()),
+				Value:   nil,
+			})
+		}
+	}
+	// lock the table (concurrent writes), and check the records count
+	var recordCount int
+	lockQuery := fmt.Sprintf("LOCK TABLE %v IN SHARE ROW EXCLUSIVE MODE", crud.TableName)
+	_, cErr := tx.Exec(context.Background(), lockQuery)
+	if cErr == nil {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %v %v", crud.TableName, delQuery.WhereQuery)
+		cErr = tx.QueryRow(context.Background(), strings.TrimSpace(countQuery)).Scan(&recordCount)
+	}
+	if cErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error counting record(s): %v", cErr.Error()),
+			Value:   nil,
+		})
+	}
+	maxCount := crud.DeleteAllMaxCount
+	if maxCount <= 0 {
+		maxCount = defaultDeleteAllMaxCount
+	}
+	if recordCount > maxCount {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Delete-all error: records count [%v] exceeded the maximum delete-all count [%v]", recordCount, maxCount),
+			Value:   nil,
+		})
+	}
+	// archive the records to the backup-table, if specified, not for the soft-delete (records retained)
+	backupTable := crud.DeleteAllBackupTable
+	if crud.SoftDelete {
+		backupTable = ""
+	}
+	if backupTable != "" {
+		createQuery, archiveQuery := "", ""
+		tableFields, bErr := helper.TableColumnNames(tx, crud.TableName)
+		if bErr == nil {
+			createQuery, archiveQuery, bErr = helper.ComputeBackupQuery(crud.TableName, backupTable, tableFields)
+		}
+		if bErr == nil {
+			_, bErr = tx.Exec(context.Background(), createQuery)
+		}
+		if bErr == nil {
+			_, bErr = tx.Exec(context.Background(), archiveQuery)
+		}
+		if bErr != nil {
+			return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error archiving record(s): %v", bErr.Error()),
+				Value:   nil,
+			})
+		}
+	}
+	commandTag, delErr := tx.Exec(context.Background(), delQuery.DeleteQuery, delQuery.FieldValues...)
+	if delErr == nil {
+		delErr = tx.Commit(context.Background())
+	}
 	if delErr != nil {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
 			Value:   nil,
 		})
 	}
+	deleteCount := int(commandTag.RowsAffected())
 
-	// delete cache, by key (TableName)
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "key")
+	// delete cache
+	crud.deleteCache()
 
 	// perform audit-log
 	logMessage := ""
 	if crud.LogDelete {
 		auditInfo := mcauditlog.PgxAuditLogOptionsType{
-			TableName:  crud.TableName,
-			LogRecords: map[string]string{"query_desc": "all-records"},
+			TableName: crud.TableName,
+			LogRecords: map[string]interface{}{
+				"query_desc":   "all-records",
+				"record_count": deleteCount,
+				"backup_table": backupTable,
+				"soft_delete":  crud.SoftDelete,
+			},
 		}
 		if logRes, logErr := crud.TransLog.AuditLog(tasks.Delete, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -103,20 +301,27 @@ func (crud *Crud) DeleteAll() mcresponse.ResponseMessage {
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: "Record(s) deleted successfully | " + logMessage,
-		Value:   commandTag.Delete(),
+		Value: types.CrudResultType{
+			RecordCount: deleteCount,
+		},
 	})
 }
 
 func (crud *Crud) DeleteByIdLog(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// get records to delete, for audit-log
-	if crud.LogDelete && len(tableFields) == len(tableFieldPointers) {
-		getRes := crud.GetById(tableFields, tableFieldPointers)
-		value, _ := getRes.Value.(types.CrudResultType)
-		crud.CurrentRecords = value.TableRecords
+	// records to delete, read in the delete transaction, for audit-log, if not returned (ReturnRecords) by the delete
+	var snapshotFields []string
+	if crud.LogDelete && !crud.ReturnRecords && len(tableFields) == len(tableFieldPointers) {
+		snapshotFields = tableFields
 	}
 
 	// perform delete-by-id
-	delRes := crud.DeleteById()
+	delRes := crud.deleteById(snapshotFields)
+	if delRes.Code != "success" {
+		return delRes
+	}
+	if delValue, ok := delRes.Value.(types.CrudResultType); ok && crud.ReturnRecords {
+		crud.CurrentRecords = delValue.TableRecords
+	}
 
 	// perform audit-log
 	logMessage := ""
@@ -140,15 +345,20 @@ func (crud *Crud) DeleteByIdLog(tableFields []string, tableFieldPointers []inter
 }
 
 func (crud *Crud) DeleteByParamLog(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// get records to delete, for audit-log
-	if crud.LogDelete && len(tableFields) == len(tableFieldPointers) {
-		getRes := crud.GetByParam(tableFields, tableFieldPointers)
-		value, _ := getRes.Value.(types.CrudResultType)
-		crud.CurrentRecords = value.TableRecords
+	// records to delete, read in the delete transaction, for audit-log, if not returned (ReturnRecords) by the delete
+	var snapshotFields []string
+	if crud.LogDelete && !crud.ReturnRecords && len(tableFields) == len(tableFieldPointers) {
+		snapshotFields = tableFields
 	}
 
 	// perform delete-by-param
-	delRes := crud.DeleteByParam()
+	delRes := crud.deleteByParam(snapshotFields)
+	if delRes.Code != "success" {
+		return delRes
+	}
+	if delValue, ok := delRes.Value.(types.CrudResultType); ok && crud.ReturnRecords {
+		crud.CurrentRecords = delValue.TableRecords
+	}
 
 	// perform audit-log
 	logMessage := ""
@@ -170,3 +380,25 @@ func (crud *Crud) DeleteByParamLog(tableFields []string, tableFieldPointers []in
 		Value:   delRes.Value,
 	})
 }
+
+// checkAdminAccess method checks the admin access (the AccessDb is required), for the restricted operation, e.g.
+// delete-all or purge, and returns the unAuthorized (or access-error) response, if not permitted
+func (crud *Crud) checkAdminAccess(operation string) (mcresponse.ResponseMessage, bool) {
+	if crud.AccessDb == nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: the access-db is required to check the admin access, for the %v operation", operation),
+			Value:   nil,
+		}), false
+	}
+	accessRes := crud.CheckUserAccess()
+	if accessRes.Code != "success" {
+		return accessRes, false
+	}
+	if accessInfo, ok := accessRes.Value.(AccessInfoType); !ok || !accessInfo.IsAdmin {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: the %v operation is permitted for admin-users only", operation),
+			Value:   nil,
+		}), false
+	}
+	return accessRes, true
+}
@@ -49,8 +49,11 @@ func TestDelete(t *testing.T) {
 		UserInfo:  TestUserInfo,
 	}
 
+	deleteAllCrudOptions := TestCrudParamOptions
+	deleteAllCrudOptions.AccessDb = dbc.DbConn
+
 	var deleteCrud = NewCrud(deleteCrudParams, TestCrudParamOptions)
-	var deleteAllCrud = NewCrud(deleteAllCrudParams, TestCrudParamOptions)
+	var deleteAllCrud = NewCrud(deleteAllCrudParams, deleteAllCrudOptions)
 
 	mctest.McTest(mctest.OptionValue{
 		Name: "should delete two records by Ids and return success:",
@@ -68,15 +71,22 @@ func TestDelete(t *testing.T) {
 			mctest.AssertEquals(t, res.Code, "success", "delete-by-params should return code: success")
 		},
 	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should refuse to delete all table records, for invalid confirm-token, and return paramsError:",
+		TestFunc: func() {
+			res := deleteAllCrud.DeleteAll(TestTable)
+			fmt.Printf("delete-all-token: %v : %v \n", res.Message, res.ResCode)
+			mctest.AssertEquals(t, res.Code, "paramsError", "delete-all should return code: paramsError")
+		},
+	})
 	mctest.McTest(mctest.OptionValue{
 		Name: "should delete all table records and return success:",
 		TestFunc: func() {
-			res := deleteAllCrud.DeleteAll()
+			res := deleteAllCrud.DeleteAll(DeleteAllTable)
 			fmt.Printf("delete-all: %v : %v \n", res.Message, res.ResCode)
-			value := res.Value
-			deleted, _ := value.(bool)
+			value, _ := res.Value.(types.CrudResultType)
 			mctest.AssertEquals(t, res.Code, "success", "delete-all should return code: success")
-			mctest.AssertEquals(t, deleted, true, "deleted() must be true")
+			mctest.AssertEquals(t, value.RecordCount >= 0, true, "delete-all record-count must be returned")
 		},
 	})
 
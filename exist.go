@@ -0,0 +1,162 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: record-exist (ExistParams duplicate) checks, for create and update
+
+package mccrud
+
+import (
+	"context"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jackc/pgx/v4"
+	"sort"
+	"strings"
+)
+
+// checkRecExist method evaluates each of the ExistParams as a duplicate check for each of the records, in the
+// (create/update) transaction, and returns the records and existParam field-names that collided with an existing
+// record or a preceding record in the records. The record's own id (update), the excludeIds and the record matching
+// the conflictFields values of the record (upsert on-conflict target) are excluded.
+func (crud *Crud) checkRecExist(tx pgx.Tx, recs types.ActionParamsType, excludeIds []string, conflictFields []string) ([]types.RecExistType, error) {
+	var existRecs []types.RecExistType
+	if len(crud.ExistParams) < 1 {
+		return existRecs, nil
+	}
+	// existParam-index and field-values of the preceding records, for duplicates within the records
+	batchValues := map[string]bool{}
+	for recNum, rec := range recs {
+		recId := ""
+		if id, ok := rec["id"].(string); ok {
+			recId = id
+		}
+		recExcludeIds := excludeIds
+		if recId != "" {
+			recExcludeIds = append([]string{recId}, excludeIds...)
+		}
+		var existFields []string
+		for existNum, existParam := range crud.ExistParams {
+			existQuery, fieldNames, fieldValues, err := helper.ComputeExistQuery(crud.TableName, existParam, rec, recExcludeIds, conflictFields)
+			if err != nil {
+				return nil, err
+			}
+			if existQuery == "" {
+				continue
+			}
+			batchKey := fmt.Sprintf("%v:%v", existNum, fieldValues[:len(fieldNames)])
+			recExist := batchValues[batchKey]
+			batchValues[batchKey] = true
+			if !recExist {
+				if err = tx.QueryRow(context.Background(), existQuery, fieldValues...).Scan(&recExist); err != nil {
+					return nil, err
+				}
+			}
+			if recExist {
+				existFields = append(existFields, fieldNames...)
+			}
+		}
+		if len(existFields) > 0 {
+			existRecs = append(existRecs, types.RecExistType{
+				RecordIndex: recNum,
+				RecordId:    recId,
+				FieldNames:  existFields,
+			})
+		}
+	}
+	return existRecs, nil
+}
+
+// checkRecExistByIds method evaluates the ExistParams duplicate check for the update-record applied to each of the
+// recordIds (update by id/params): the recordIds are excluded from the existing records check, and, for more than
+// one record, any existParam with all its field-values specified by the update-record is a duplicate.
+func (crud *Crud) checkRecExistByIds(tx pgx.Tx, rec types.ActionParamType, recordIds []string) ([]types.RecExistType, error) {
+	existRecs, err := crud.checkRecExist(tx, types.ActionParamsType{rec}, recordIds, nil)
+	if err != nil || len(existRecs) > 0 || len(recordIds) < 2 {
+		return existRecs, err
+	}
+	var existFields []string
+	for _, existParam := range crud.ExistParams {
+		var fieldNames []string
+		for fieldName := range existParam {
+			if rec[fieldName] == nil {
+				fieldNames = nil
+				break
+			}
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		existFields = append(existFields, fieldNames...)
+	}
+	if len(existFields) > 0 {
+		existRecs = append(existRecs, types.RecExistType{
+			RecordIndex: 0,
+			FieldNames:  existFields,
+		})
+	}
+	return existRecs, nil
+}
+
+// queryParamsIds method returns the ids of the records that met the query-params, in the transaction, e.g. the
+// records to update by params
+func (crud *Crud) queryParamsIds(tx pgx.Tx) ([]string, error) {
+	whereRes, err := helper.ComputeWhereQuery(crud.QueryParams, 0)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(context.Background(), fmt.Sprintf("SELECT id FROM %v %v", crud.TableName, whereRes.WhereQuery), whereRes.FieldValues...)
+	if err != nil {
+		return nil, err
+	}
+	recordIds, _, err := scanReturning(rows, false)
+	return recordIds, err
+}
+
+// checkTempTableRecExist method evaluates each of the ExistParams as a duplicate check for the records loaded into
+// the temp-table (copy/staged create), in the transaction, see helper.ComputeTempTableExistQuery. The collided
+// existParam field-names are returned for the records (RecordIndex: -1).
+func (crud *Crud) checkTempTableRecExist(tx pgx.Tx, tempTableName string, tableFields []string, conflictFields []string) ([]types.RecExistType, error) {
+	var existRecs []types.RecExistType
+	var existFields []string
+	for _, existParam := range crud.ExistParams {
+		existQuery, fieldNames, fieldValues, err := helper.ComputeTempTableExistQuery(crud.TableName, tempTableName, existParam, tableFields, conflictFields)
+		if err != nil {
+			return nil, err
+		}
+		if existQuery == "" {
+			continue
+		}
+		recExist := false
+		if err = tx.QueryRow(context.Background(), existQuery, fieldValues...).Scan(&recExist); err != nil {
+			return nil, err
+		}
+		if recExist {
+			existFields = append(existFields, fieldNames...)
+		}
+	}
+	if len(existFields) > 0 {
+		existRecs = append(existRecs, types.RecExistType{
+			RecordIndex: -1,
+			FieldNames:  existFields,
+		})
+	}
+	return existRecs, nil
+}
+
+// recExistResponse method returns the recExist response, with the RecExistMessage and the collided records/fields
+func (crud *Crud) recExistResponse(existRecs []types.RecExistType) mcresponse.ResponseMessage {
+	var existItems []string
+	for _, existRec := range existRecs {
+		if existRec.RecordIndex < 0 {
+			existItems = append(existItems, fmt.Sprintf("records [%v]", strings.Join(existRec.FieldNames, ", ")))
+			continue
+		}
+		existItems = append(existItems, fmt.Sprintf("record #%v [%v]", existRec.RecordIndex, strings.Join(existRec.FieldNames, ", ")))
+	}
+	res := mcresponse.GetResMessage("exists", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v: %v", crud.RecExistMessage, strings.Join(existItems, "; ")),
+		Value:   existRecs,
+	})
+	res.Code = "recExist"
+	return res
+}
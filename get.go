@@ -7,54 +7,319 @@ package mccrud
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/abbeymart/mcauditlog"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mccrud/helper"
 	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mcresponse"
 	"github.com/abbeymart/mctypes/tasks"
+	"strings"
 	"time"
 )
 
-// GetById method fetches/gets/reads record(s) that met the specified record-id(s),
-// constrained by optional skip and limit parameters
-func (crud *Crud) GetById(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// check cache
-	getCacheRes := mccache.GetHashCache(crud.TableName, crud.HashKey)
-	val, ok := getCacheRes.Value.([]interface{})
-	if getCacheRes.Ok && ok && len(val) > 0 {
-		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-			Message: "records successfully retrieved from the cache",
-			Value: types.CrudResultType{
-				QueryParam:   crud.QueryParams,
-				RecordIds:    crud.RecordIds,
-				RecordCount:  len(val),
-				TableRecords: val,
-			},
-		})
+// computeSortQuery method computes the order-by script from the SortItems or, if not specified, the SortParams.
+// The sort field-names are validated against the projected tableFields
+func (crud *Crud) computeSortQuery(tableFields []string) (string, error) {
+	sortItems := crud.SortItems
+	if len(sortItems) < 1 {
+		sortItems = helper.ComputeSortItems(crud.SortParams)
+	}
+	return helper.ComputeSortQuery(sortItems, tableFields)
+}
+
+// computePageQuery method computes the order-by, limit and offset scripts, for the select/read-query
+func (crud *Crud) computePageQuery(tableFields []string) (string, error) {
+	pageQuery, err := crud.computeSortQuery(tableFields)
+	if err != nil {
+		return "", err
+	}
+	if crud.Limit > 0 {
+		pageQuery += fmt.Sprintf(" LIMIT %v", crud.Limit)
+	}
+	if crud.Skip > 0 {
+		pageQuery += fmt.Sprintf(" OFFSET %v", crud.Skip)
 	}
+	return pageQuery, nil
+}
+
+// scanRecord method extracts the current row values, from the tableFieldPointers, by the tableFields
+func scanRecord(tableFields []string, tableFieldPointers []interface{}) (map[string]interface{}, error) {
+	getResult := map[string]interface{}{}
+	for i, fieldPointer := range tableFieldPointers {
+		switch fieldPointer.(type) {
+		case *time.Time:
+			val := fieldPointer.(*time.Time)
+			getResult[tableFields[i]] = *val
+		case *string:
+			val := fieldPointer.(*string)
+			getResult[tableFields[i]] = *val
+		case *int:
+			val := fieldPointer.(*int)
+			getResult[tableFields[i]] = *val
+		case *float64:
+			val := fieldPointer.(*float64)
+			getResult[tableFields[i]] = *val
+		case *interface{}:
+			val := fieldPointer.(*interface{})
+			getResult[tableFields[i]] = *val
+		default:
+			// avoid panic, return unsupported type
+			return nil, errors.New(fmt.Sprintf("Unsupportted fieldName [%v] type %v", tableFields[i], fieldPointer))
+		}
+	}
+	return getResult, nil
+}
+
+// jsonRecord function transforms the record-value into json-value-format
+func jsonRecord(rec map[string]interface{}) (map[string]interface{}, error) {
+	jByte, jErr := json.Marshal(rec)
+	if jErr != nil {
+		return nil, jErr
+	}
+	var gValue map[string]interface{}
+	if jErr = json.Unmarshal(jByte, &gValue); jErr != nil {
+		return nil, jErr
+	}
+	return gValue, nil
+}
+
+// computeCursor function computes the opaque next/prev-cursor from the record's keyset/sort-fields values
+func computeCursor(direction string, keysetItems types.SortItemsType, rec map[string]interface{}) (string, error) {
+	cursor := types.CursorType{Direction: direction}
+	for _, item := range keysetItems {
+		cursor.FieldNames = append(cursor.FieldNames, item.FieldName)
+		cursor.SortOrders = append(cursor.SortOrders, item.Order)
+		cursor.Values = append(cursor.Values, rec[item.FieldName])
+	}
+	return helper.EncodeCursor(cursor)
+}
+
+// getByCursor method fetches/gets/reads a page (up to limit) of the record(s) that met the where-params (all
+// records, if not specified), after/before the record of the cursor (first page, if not specified), by keyset/cursor
+// paging on the sort-params and id. The result includes the next/prev-cursor for the next/previous page, if any.
+func (crud *Crud) getByCursor(where types.QueryParamType, tableFields []string, tableFieldPointers []interface{}, logRecords interface{}) mcresponse.ResponseMessage {
 	// SELECT/scan to tableFieldPointers, in order specified by the tableFields
-	// tableFields and tableFieldPointers length and order must match
 	if len(tableFields) != len(tableFieldPointers) {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("tableFields Count [%v] and tableFieldPointer Count [%v] must be the same", len(tableFields), len(tableFieldPointers)),
 			Value:   nil,
 		})
 	}
-	getQuery, err := helper.ComputeSelectQueryById(crud.TableName, crud.RecordIds, tableFields)
+	// search-aware select-query, i.e. the search_rank field, as for the getByParam
+	selectQuery, err := crud.computeSelectQuery(where, tableFields)
+	if err != nil {
+		return errorResponse(err, "readError", "Error computing select/read-query")
+	}
+	// keyset: sort-params and id (tie-breaker)
+	sortItems := crud.SortItems
+	if len(sortItems) < 1 {
+		sortItems = helper.ComputeSortItems(crud.SortParams)
+	}
+	keysetItems, err := helper.ComputeKeysetSortItems(sortItems)
 	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// compute the keyset condition, from the cursor
+	direction := types.CursorNext
+	queryItems := keysetItems
+	getQuery := selectQuery.SelectQuery
+	fieldValues := selectQuery.FieldValues
+	if crud.Cursor != "" {
+		cursor, err := helper.DecodeCursor(crud.Cursor)
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		// the cursor must be from a read with the same sort-params
+		if len(cursor.FieldNames) != len(keysetItems) {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: "Error computing sort/paging-query: cursor does not match the sort-params",
+				Value:   nil,
+			})
+		}
+		for i, item := range keysetItems {
+			if cursor.FieldNames[i] != item.FieldName || cursor.SortOrders[i] != item.Order {
+				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+					Message: "Error computing sort/paging-query: cursor does not match the sort-params",
+					Value:   nil,
+				})
+			}
+		}
+		direction = cursor.Direction
+		if direction == types.CursorPrev {
+			// read backwards, from the cursor record
+			queryItems = helper.ReverseSortItems(keysetItems)
+		}
+		keysetQuery, keysetValues, err := helper.ComputeKeysetQuery(queryItems, cursor.Values, len(fieldValues))
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		// keyset condition on the select-query records, i.e. including the (computed) search_rank field
+		getQuery = fmt.Sprintf("SELECT %v FROM (%v) AS cursor_records WHERE %v", strings.Join(tableFields, ", "), getQuery, keysetQuery)
+		fieldValues = append(fieldValues, keysetValues...)
+	}
+	sortQuery, err := helper.ComputeSortQuery(queryItems, tableFields)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	limit := crud.Limit
+	if limit <= 0 {
+		limit = crud.MaxQueryLimit
+	}
+	// fetch an extra record, to determine if there is a next/previous page
+	getQuery += fmt.Sprintf(" %v LIMIT %v", sortQuery, limit+1)
+	// perform crud-task action
+	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery, fieldValues...)
+	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error computing select/read-query: %v", err.Error()),
-			Value:   getQuery,
+			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
+			Value:   nil,
 		})
 	}
-	// include options: limit... TODO: sort?
-	if crud.Limit > 0 {
-		getQuery += fmt.Sprintf(" LIMIT %v", crud.Limit)
+	defer rows.Close()
+	var records []map[string]interface{}
+	for rows.Next() {
+		if rowScanErr := rows.Scan(tableFieldPointers...); rowScanErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading/getting records[row-scan]: %v", rowScanErr.Error()),
+				Value:   nil,
+			})
+		}
+		rec, recErr := scanRecord(tableFields, tableFieldPointers)
+		if recErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: recErr.Error(),
+				Value:   nil,
+			})
+		}
+		records = append(records, rec)
+	}
+	if rowErr := rows.Err(); rowErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading/getting records: %v", rowErr.Error()),
+			Value:   nil,
+		})
+	}
+	hasMore := len(records) > limit
+	if hasMore {
+		records = records[:limit]
+	}
+	if direction == types.CursorPrev {
+		// restore the sort-params order
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+	}
+	// compute next/prev-cursor, from the last/first record
+	var nextCursor, prevCursor string
+	if len(records) > 0 {
+		if (direction == types.CursorNext && hasMore) || direction == types.CursorPrev {
+			nextCursor, err = computeCursor(types.CursorNext, keysetItems, records[len(records)-1])
+		}
+		if err == nil && ((direction == types.CursorPrev && hasMore) || (direction == types.CursorNext && crud.Cursor != "")) {
+			prevCursor, err = computeCursor(types.CursorPrev, keysetItems, records[0])
+		}
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error computing next/prev-cursor: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+	}
+	// transform records to json-value-format
+	var getResults []interface{}
+	for _, rec := range records {
+		gValue, jErr := jsonRecord(rec)
+		if jErr != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
+				Value:   nil,
+			})
+		}
+		getResults = append(getResults, gValue)
+	}
+	// include/nest the related records
+	if relErr := crud.includeRelations(getResults); relErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error including relations: %v", relErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	// perform audit-log
+	logMessage := ""
+	if crud.LogRead {
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName:  crud.TableName,
+			LogRecords: logRecords,
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Read, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: logMessage,
+		Value: types.CrudResultType{
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  len(getResults),
+			TableRecords: getResults,
+			NextCursor:   nextCursor,
+			PrevCursor:   prevCursor,
+		},
+	})
+}
+
+// GetById method fetches/gets/reads record(s) that met the specified record-id(s),
+// ordered by the sort-params (default: id), constrained by optional skip and limit parameters,
+// including the related records of the IncludeRelations, if specified. The result is cached (read-through).
+func (crud *Crud) GetById(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
+	return crud.readThrough("get-by-id", tableFields, crud.RecordIds, func() mcresponse.ResponseMessage {
+		return crud.getById(tableFields, tableFieldPointers)
+	})
+}
+
+// getById method reads the records by record-id(s), i.e. the GetById (read-through cache) miss
+func (crud *Crud) getById(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
+	// SELECT/scan to tableFieldPointers, in order specified by the tableFields
+	// tableFields and tableFieldPointers length and order must match
+	if len(tableFields) != len(tableFieldPointers) {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("tableFields Count [%v] and tableFieldPointer Count [%v] must be the same", len(tableFields), len(tableFieldPointers)),
+			Value:   nil,
+		})
+	}
+	// the record-ids where-params, composed with the not-deleted condition, for the SoftDelete option
+	selectQuery, err := crud.computeSelectQuery(crud.computeQueryParams(), tableFields)
+	if err != nil {
+		return errorResponse(err, "readError", "Error computing select/read-query")
 	}
+	// include options: sort, skip && limit
+	pageQuery, err := crud.computePageQuery(tableFields)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	getQuery := selectQuery.SelectQuery + " " + pageQuery
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery)
+	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery, selectQuery.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -65,73 +330,44 @@ func (crud *Crud) GetById(tableFields []string, tableFieldPointers []interface{}
 	// check rows count
 	var rowCount = 0
 	var getResults []interface{}
-	var getResult = map[string]interface{}{}
 	for rows.Next() {
 		if rowScanErr := rows.Scan(tableFieldPointers...); rowScanErr != nil {
 			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 				Message: fmt.Sprintf("Error reading/getting records[row-scan]: %v", rowScanErr.Error()),
 				Value:   nil,
 			})
-		} else {
-			// extract values from tableFieldPointers
-			for i, fieldPointer := range tableFieldPointers {
-				switch fieldPointer.(type) {
-				case *time.Time:
-					val := fieldPointer.(*time.Time)
-					getResult[tableFields[i]] = *val
-				case *string:
-					val := fieldPointer.(*string)
-					getResult[tableFields[i]] = *val
-				case *int:
-					val := fieldPointer.(*int)
-					getResult[tableFields[i]] = *val
-				case *float64:
-					val := fieldPointer.(*float64)
-					getResult[tableFields[i]] = *val
-				case *interface{}:
-					val := fieldPointer.(*interface{})
-					getResult[tableFields[i]] = *val
-				default:
-					// avoid panic, return unsupported type
-					return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
-						Message: fmt.Sprintf("Unsupportted fieldName [%v] type %v", tableFields[i], fieldPointer),
-						Value:   nil,
-					})
-				}
-			}
-			// getChan <- rowCount // pass the scanned result alert to getChan | will block until read
-			// get snapshot value from the pointer | transform value to json-value-format
-			jByte, jErr := json.Marshal(getResult)
-			if jErr != nil {
-				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
-					Value:   nil,
-				})
-			}
-			var gValue map[string]interface{}
-			jErr = json.Unmarshal(jByte, &gValue)
-			if jErr != nil {
-				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
-					Value:   nil,
-				})
-			}
-			getResults = append(getResults, gValue)
-			rowCount += 1
 		}
+		rec, recErr := scanRecord(tableFields, tableFieldPointers)
+		if recErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: recErr.Error(),
+				Value:   nil,
+			})
+		}
+		// transform record to json-value-format
+		gValue, jErr := jsonRecord(rec)
+		if jErr != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
+				Value:   nil,
+			})
+		}
+		getResults = append(getResults, gValue)
+		rowCount += 1
 	}
-	// close channel
-	//close(getChan)
-
 	if err := rows.Err(); err != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error reading/getting records: %v", err.Error()),
 			Value:   nil,
 		})
 	}
-	// update cache
-	_ = mccache.SetHashCache(crud.TableName, crud.HashKey, getResults, uint(crud.CacheExpire))
-
+	// include/nest the related records
+	if relErr := crud.includeRelations(getResults); relErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error including relations: %v", relErr.Error()),
+			Value:   nil,
+		})
+	}
 	// perform audit-log
 	logMessage := ""
 	if crud.LogRead {
@@ -158,21 +394,20 @@ func (crud *Crud) GetById(tableFields []string, tableFieldPointers []interface{}
 }
 
 // GetByParam method fetches/gets/reads record(s) that met the specified query-params or where conditions,
-// constrained by optional skip and limit parameters
+// ordered by the sort-params (default: id), constrained by optional skip and limit parameters,
+// or by the cursor and limit parameters, for cursor-paging, including the related records of the IncludeRelations.
+// The result is cached (read-through).
 func (crud *Crud) GetByParam(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// check cache
-	getCacheRes := mccache.GetHashCache(crud.TableName, crud.HashKey)
-	val, ok := getCacheRes.Value.([]interface{})
-	if getCacheRes.Ok && ok && len(val) > 0 {
-		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-			Message: "records successfully retrieved from the cache",
-			Value: types.CrudResultType{
-				QueryParam:   crud.QueryParams,
-				RecordIds:    crud.RecordIds,
-				RecordCount:  len(val),
-				TableRecords: val,
-			},
-		})
+	return crud.readThrough("get-by-param", tableFields, crud.QueryParams, func() mcresponse.ResponseMessage {
+		return crud.getByParam(tableFields, tableFieldPointers)
+	})
+}
+
+// getByParam method reads the records by query-params, i.e. the GetByParam (read-through cache) miss
+func (crud *Crud) getByParam(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
+	// cursor-paging, in place of skip/offset paging
+	if crud.CursorPaging {
+		return crud.getByCursor(crud.QueryParams, tableFields, tableFieldPointers, crud.QueryParams)
 	}
 	// SELECT/scan to tableFieldPointers, in order specified by the tableFields
 	if len(tableFields) != len(tableFieldPointers) {
@@ -182,20 +417,22 @@ func (crud *Crud) GetByParam(tableFields []string, tableFieldPointers []interfac
 		})
 	}
 	logMessage := ""
-	getQuery, err := helper.ComputeSelectQueryByParam(crud.TableName, crud.QueryParams, tableFields)
+	selectQuery, err := crud.computeSelectQuery(crud.QueryParams, tableFields)
 	if err != nil {
-		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error computing select/read-query: %v", err.Error()),
-			Value:   getQuery,
-		})
+		return errorResponse(err, "readError", "Error computing select/read-query")
 	}
-	// include options: limit TODO: sort?
-	if crud.Limit > 0 {
-		getQuery += fmt.Sprintf(" LIMIT %v", crud.Limit)
+	// include options: sort, skip && limit
+	pageQuery, err := crud.computePageQuery(tableFields)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+			Value:   nil,
+		})
 	}
+	getQuery := selectQuery.SelectQuery + " " + pageQuery
 	// perform crud-task action
 	//fmt.Printf("getQuery-param: %v\n", getQuery)
-	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery)
+	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery, selectQuery.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -206,59 +443,30 @@ func (crud *Crud) GetByParam(tableFields []string, tableFieldPointers []interfac
 	// check rows count
 	var rowCount = 0
 	var getResults []interface{}
-	var getResult = map[string]interface{}{}
 	for rows.Next() {
 		if rowScanErr := rows.Scan(tableFieldPointers...); rowScanErr != nil {
 			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 				Message: fmt.Sprintf("Error reading/getting records[row-scan]: %v", rowScanErr.Error()),
 				Value:   nil,
 			})
-		} else {
-			// extract values from tableFieldPointers
-			for i, fieldPointer := range tableFieldPointers {
-				switch fieldPointer.(type) {
-				case *time.Time:
-					val := fieldPointer.(*time.Time)
-					getResult[tableFields[i]] = *val
-				case *string:
-					val := fieldPointer.(*string)
-					getResult[tableFields[i]] = *val
-				case *int:
-					val := fieldPointer.(*int)
-					getResult[tableFields[i]] = *val
-				case *float64:
-					val := fieldPointer.(*float64)
-					getResult[tableFields[i]] = *val
-				case *interface{}:
-					val := fieldPointer.(*interface{})
-					getResult[tableFields[i]] = *val
-				default:
-					// avoid panic, return unsupported type
-					return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
-						Message: fmt.Sprintf("Unsupportted fieldName [%v] type %v", tableFields[i], fieldPointer),
-						Value:   nil,
-					})
-				}
-			}
-			// get snapshot value from the pointer | transform value to json-value-format
-			jByte, jErr := json.Marshal(getResult)
-			if jErr != nil {
-				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
-					Value:   nil,
-				})
-			}
-			var gValue map[string]interface{}
-			jErr = json.Unmarshal(jByte, &gValue)
-			if jErr != nil {
-				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
-					Value:   nil,
-				})
-			}
-			getResults = append(getResults, gValue)
-			rowCount += 1
 		}
+		rec, recErr := scanRecord(tableFields, tableFieldPointers)
+		if recErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: recErr.Error(),
+				Value:   nil,
+			})
+		}
+		// transform record to json-value-format
+		gValue, jErr := jsonRecord(rec)
+		if jErr != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
+				Value:   nil,
+			})
+		}
+		getResults = append(getResults, gValue)
+		rowCount += 1
 	}
 
 	if rowErr := rows.Err(); rowErr != nil {
@@ -273,9 +481,13 @@ func (crud *Crud) GetByParam(tableFields []string, tableFieldPointers []interfac
 		})
 	}
 
-	// update cache
-	_ = mccache.SetHashCache(crud.TableName, crud.HashKey, getResults, uint(crud.CacheExpire))
-
+	// include/nest the related records
+	if relErr := crud.includeRelations(getResults); relErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error including relations: %v", relErr.Error()),
+			Value:   nil,
+		})
+	}
 	// perform audit-log
 	if crud.LogRead {
 		auditInfo := mcauditlog.PgxAuditLogOptionsType{
@@ -300,8 +512,22 @@ func (crud *Crud) GetByParam(tableFields []string, tableFieldPointers []interfac
 	})
 }
 
-// GetAll method fetches/gets/reads all record(s), constrained by optional skip and limit parameters
+// GetAll method fetches/gets/reads all record(s), ordered by the sort-params (default: id),
+// constrained by optional skip and limit parameters, or by the cursor and limit parameters, for cursor-paging.
+// The result is cached (read-through).
 func (crud *Crud) GetAll(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
+	return crud.readThrough("get-all", tableFields, map[string]string{"query_desc": "all-records"}, func() mcresponse.ResponseMessage {
+		return crud.getAll(tableFields, tableFieldPointers)
+	})
+}
+
+// getAll method reads all the records, i.e. the GetAll (read-through cache) miss
+func (crud *Crud) getAll(tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
+	// cursor-paging, in place of skip/offset paging
+	if crud.CursorPaging {
+		// all (not-deleted) records
+		return crud.getByCursor(nil, tableFields, tableFieldPointers, map[string]string{"query_desc": "all-records"})
+	}
 	// SELECT/scan to tableFieldPointers, in order specified by the tableFields
 	if len(tableFields) != len(tableFieldPointers) {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
@@ -310,22 +536,21 @@ func (crud *Crud) GetAll(tableFields []string, tableFieldPointers []interface{})
 		})
 	}
 	logMessage := ""
-	getQuery, err := helper.ComputeSelectQueryAll(crud.TableName, tableFields)
+	selectQuery, err := crud.computeSelectQuery(nil, tableFields)
 	if err != nil {
-		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error computing select/read-query: %v", err.Error()),
-			Value:   getQuery,
-		})
+		return errorResponse(err, "readError", "Error computing select/read-query")
 	}
-	// include options: skip && limit TODO: sort?
-	if crud.Limit > 0 {
-		getQuery += fmt.Sprintf(" LIMIT %v", crud.Limit)
-	}
-	if crud.Skip > 0 {
-		getQuery += fmt.Sprintf(" OFFSET %v", crud.Skip)
+	// include options: sort, skip && limit
+	pageQuery, err := crud.computePageQuery(tableFields)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()),
+			Value:   nil,
+		})
 	}
+	getQuery := selectQuery.SelectQuery + " " + pageQuery
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery)
+	rows, qRowErr := crud.AppDb.Query(context.Background(), getQuery, selectQuery.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -336,59 +561,30 @@ func (crud *Crud) GetAll(tableFields []string, tableFieldPointers []interface{})
 	// check rows count
 	var rowCount = 0
 	var getResults []interface{}
-	getResult := map[string]interface{}{}
 	for rows.Next() {
 		if rowScanErr := rows.Scan(tableFieldPointers...); rowScanErr != nil {
 			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 				Message: fmt.Sprintf("Error reading/getting records[row-scan]: %v", rowScanErr.Error()),
 				Value:   nil,
 			})
-		} else {
-			// extract values from tableFieldPointers
-			for i, fieldPointer := range tableFieldPointers {
-				switch fieldPointer.(type) {
-				case *time.Time:
-					val := fieldPointer.(*time.Time)
-					getResult[tableFields[i]] = *val
-				case *string:
-					val := fieldPointer.(*string)
-					getResult[tableFields[i]] = *val
-				case *int:
-					val := fieldPointer.(*int)
-					getResult[tableFields[i]] = *val
-				case *float64:
-					val := fieldPointer.(*float64)
-					getResult[tableFields[i]] = *val
-				case *interface{}:
-					val := fieldPointer.(*interface{})
-					getResult[tableFields[i]] = *val
-				default:
-					// avoid panic, return unsupported type
-					return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
-						Message: fmt.Sprintf("Unsupportted fieldName [%v] type %v", tableFields[i], fieldPointer),
-						Value:   nil,
-					})
-				}
-			}
-			// get snapshot value from the pointer | transform value to json-value-format
-			jByte, jErr := json.Marshal(getResult)
-			if jErr != nil {
-				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
-					Value:   nil,
-				})
-			}
-			var gValue map[string]interface{}
-			jErr = json.Unmarshal(jByte, &gValue)
-			if jErr != nil {
-				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
-					Value:   nil,
-				})
-			}
-			getResults = append(getResults, gValue)
-			rowCount += 1
 		}
+		rec, recErr := scanRecord(tableFields, tableFieldPointers)
+		if recErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: recErr.Error(),
+				Value:   nil,
+			})
+		}
+		// transform record to json-value-format
+		gValue, jErr := jsonRecord(rec)
+		if jErr != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
+				Value:   nil,
+			})
+		}
+		getResults = append(getResults, gValue)
+		rowCount += 1
 	}
 
 	if rowErr := rows.Err(); rowErr != nil {
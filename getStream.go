@@ -1,15 +1,183 @@
-// @Author: abbeymart | Abi Akindele | @Created: 2020-12-01 | @Updated: 2020-12-01
+// @Author: abbeymart | Abi Akindele | @Created: 2020-12-01 | @Updated: 2026-10-18
 // @Company: mConnect.biz | @License: MIT
 // @Description: get / query - stream record(s)
 
 package mccrud
 
-import "github.com/abbeymart/mcresponse"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcauditlog"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctypes/tasks"
+	"github.com/jackc/pgx/v4"
+)
 
-func (crud Crud) GetStream() mcresponse.ResponseMessage {
+// streamCursorName is the server-side cursor name for the GetStream query
+const streamCursorName = "mccrud_stream_cursor"
+
+// defaultFetchSize is the default number of records fetched per stream-cursor round-trip
+const defaultFetchSize = 1000
+
+// computeStreamQuery method computes the select-query and placeholder-values for the record-ids, query-params or
+// all records, ordered by the sort-params (default: id), constrained by optional skip and limit parameters
+func (crud *Crud) computeStreamQuery(tableFields []string) (string, []interface{}, error) {
+	selectQuery, err := crud.computeSelectQuery(crud.computeQueryParams(), tableFields)
+	if _, ok := err.(paramsError); ok {
+		return "", nil, err
+	}
+	if err != nil {
+		return "", nil, errors.New(fmt.Sprintf("Error computing select/read-query: %v", err.Error()))
+	}
+	pageQuery, err := crud.computePageQuery(tableFields)
+	if err != nil {
+		return "", nil, errors.New(fmt.Sprintf("Error computing sort/paging-query: %v", err.Error()))
+	}
+	return selectQuery.SelectQuery + " " + pageQuery, selectQuery.FieldValues, nil
+}
+
+// GetStream method streams the record(s) that met the specified record-id(s), query-params or all records, ordered by
+// the sort-params (default: id), constrained by optional skip and limit parameters.
+// The records are read from a server-side cursor, in batches of FetchSize, within a read-only transaction, and sent
+// (json-value-format) over the response Value (types.RecordStreamType) Records channel, which is closed at the end of
+// the stream. The next batch is fetched only as the records are received (backpressure). A read error is sent as the
+// last channel value (Err). The stream holds a pool connection (transaction) until the end of the stream: receive the
+// Records until the channel is closed, or call the Stop function (or cancel the ctx) to stop the stream early, i.e.
+// an abandoned stream, not stopped, blocks its go-routine and connection.
+// The tableFieldPointers are used by the stream go-routine and must not be accessed until the channel is closed.
+func (crud *Crud) GetStream(ctx context.Context, tableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
+	// check task-permission - get/read
+	if crud.CheckAccess {
+		accessRes := crud.TaskPermission(tasks.Read)
+		if accessRes.Code != "success" {
+			return accessRes
+		}
+	}
+	// SELECT/scan to tableFieldPointers, in order specified by the tableFields
+	if len(tableFields) != len(tableFieldPointers) {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("tableFields Count [%v] and tableFieldPointer Count [%v] must be the same", len(tableFields), len(tableFieldPointers)),
+			Value:   nil,
+		})
+	}
+	getQuery, fieldValues, err := crud.computeStreamQuery(tableFields)
+	if err != nil {
+		return errorResponse(err, "paramsError", "Error computing stream-query")
+	}
+	// server-side cursor, valid within the (read-only) transaction
+	tx, txErr := crud.AppDb.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if txErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error starting the stream transaction: %v", txErr.Error()),
+			Value:   nil,
+		})
+	}
+	if _, cErr := tx.Exec(ctx, fmt.Sprintf("DECLARE %v NO SCROLL CURSOR FOR %v", streamCursorName, getQuery), fieldValues...); cErr != nil {
+		_ = tx.Rollback(context.Background())
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", cErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	// perform audit-log
+	logMessage := ""
+	if crud.LogRead {
+		var logRecords interface{} = map[string]string{"query_desc": "all-records"}
+		if len(crud.RecordIds) > 0 {
+			logRecords = crud.RecordIds
+		} else if len(crud.QueryParams) > 0 {
+			logRecords = crud.QueryParams
+		}
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName:  crud.TableName,
+			LogRecords: logRecords,
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Read, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+
+	streamCtx, stopStream := context.WithCancel(ctx)
+	streamChan := make(chan types.StreamRecordType)
+	go crud.streamRecords(streamCtx, stopStream, tx, tableFields, tableFieldPointers, streamChan)
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: "success",
-		Value:   nil,
+		Message: logMessage,
+		Value:   types.RecordStreamType{Records: streamChan, Stop: stopStream},
 	})
-}
\ No newline at end of file
+}
+
+// streamRecords method fetches the stream-cursor records, in batches of FetchSize, and sends each record to the
+// streamChan, until the end of the records, an error or the ctx cancellation (e.g. the stream Stop). The streamChan
+// is closed, and the ctx released (stopStream), on return.
+func (crud *Crud) streamRecords(ctx context.Context, stopStream context.CancelFunc, tx pgx.Tx, tableFields []string, tableFieldPointers []interface{}, streamChan chan<- types.StreamRecordType) {
+	defer stopStream()
+	defer close(streamChan)
+	// send the stream error, unless cancelled
+	sendErr := func(err error) {
+		_ = tx.Rollback(context.Background())
+		select {
+		case streamChan <- types.StreamRecordType{Err: err}:
+		case <-ctx.Done():
+		}
+	}
+	fetchSize := crud.FetchSize
+	if fetchSize <= 0 {
+		fetchSize = defaultFetchSize
+	}
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %v FROM %v", fetchSize, streamCursorName)
+	for {
+		rows, qRowErr := tx.Query(ctx, fetchQuery)
+		if qRowErr != nil {
+			sendErr(errors.New(fmt.Sprintf("Db query Error: %v", qRowErr.Error())))
+			return
+		}
+		rowCount := 0
+		for rows.Next() {
+			rowCount += 1
+			if rowScanErr := rows.Scan(tableFieldPointers...); rowScanErr != nil {
+				rows.Close()
+				sendErr(errors.New(fmt.Sprintf("Error reading/getting records[row-scan]: %v", rowScanErr.Error())))
+				return
+			}
+			rec, recErr := scanRecord(tableFields, tableFieldPointers)
+			if recErr == nil {
+				rec, recErr = jsonRecord(rec)
+			}
+			if recErr != nil {
+				rows.Close()
+				sendErr(recErr)
+				return
+			}
+			// blocks until received (backpressure) or cancelled
+			select {
+			case streamChan <- types.StreamRecordType{Record: rec}:
+			case <-ctx.Done():
+				rows.Close()
+				_ = tx.Rollback(context.Background())
+				return
+			}
+		}
+		rows.Close()
+		if rowErr := rows.Err(); rowErr != nil {
+			sendErr(errors.New(fmt.Sprintf("Error reading/getting records: %v", rowErr.Error())))
+			return
+		}
+		// end of the records
+		if rowCount < fetchSize {
+			break
+		}
+	}
+	if _, err := tx.Exec(ctx, "CLOSE "+streamCursorName); err != nil {
+		sendErr(errors.New(fmt.Sprintf("Error closing the stream cursor: %v", err.Error())))
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		sendErr(errors.New(fmt.Sprintf("Error closing the stream transaction: %v", err.Error())))
+	}
+}
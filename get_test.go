@@ -5,11 +5,13 @@
 package mccrud
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mcdb"
 	"github.com/abbeymart/mctest"
+	"github.com/abbeymart/mctypes"
 	"testing"
 	"time"
 )
@@ -148,6 +150,222 @@ func TestGet(t *testing.T) {
 		},
 	})
 
+	mctest.McTest(mctest.OptionValue{
+		Name: "should get all records sorted by log_at(desc) and return success:",
+		TestFunc: func() {
+			var (
+				id            string
+				tableName     string
+				logRecords    interface{}
+				newLogRecords interface{}
+				logBy         string
+				logType       string
+				logAt         time.Time
+			)
+			getCrud.Skip = 0
+			getCrud.Limit = 20
+			getCrud.SortItems = types.SortItemsType{{FieldName: "log_at", Order: -1, Nulls: "last"}}
+			tableFieldPointers := []interface{}{&id, &tableName, &logRecords, &newLogRecords, &logBy, &logType, &logAt}
+			res := getCrud.GetAll(GetTableFields, tableFieldPointers)
+			getCrud.SortItems = nil
+			value, _ := res.Value.(types.CrudResultType)
+			fmt.Printf("get-by-all-sorted-count: %v\n", value.RecordCount)
+			mctest.AssertEquals(t, res.Code, "success", "get-task should return code: success")
+			mctest.AssertEquals(t, value.RecordCount == 20, true, "get-task-count should be = 20")
+			firstRec, _ := value.TableRecords[0].(map[string]interface{})
+			lastRec, _ := value.TableRecords[len(value.TableRecords)-1].(map[string]interface{})
+			mctest.AssertEquals(t, fmt.Sprintf("%v", firstRec["log_at"]) >= fmt.Sprintf("%v", lastRec["log_at"]), true, "first-record log_at should be >= last-record log_at")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return paramsError for sort by a non-projected field:",
+		TestFunc: func() {
+			var (
+				id            string
+				tableName     string
+				logRecords    interface{}
+				newLogRecords interface{}
+				logBy         string
+				logType       string
+				logAt         time.Time
+			)
+			getCrud.SortItems = types.SortItemsType{{FieldName: "log_at; DROP TABLE audits", Order: 1}}
+			tableFieldPointers := []interface{}{&id, &tableName, &logRecords, &newLogRecords, &logBy, &logType, &logAt}
+			res := getCrud.GetAll(GetTableFields, tableFieldPointers)
+			getCrud.SortItems = nil
+			mctest.AssertEquals(t, res.Code, "paramsError", "get-task should return code: paramsError")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should get all records by cursor(next-page) and return success:",
+		TestFunc: func() {
+			var (
+				id            string
+				tableName     string
+				logRecords    interface{}
+				newLogRecords interface{}
+				logBy         string
+				logType       string
+				logAt         time.Time
+			)
+			getCrud.CursorPaging = true
+			getCrud.Cursor = ""
+			getCrud.Limit = 5
+			getCrud.SortItems = types.SortItemsType{{FieldName: "log_at", Order: -1}}
+			tableFieldPointers := []interface{}{&id, &tableName, &logRecords, &newLogRecords, &logBy, &logType, &logAt}
+			res := getCrud.GetAll(GetTableFields, tableFieldPointers)
+			value, _ := res.Value.(types.CrudResultType)
+			mctest.AssertEquals(t, res.Code, "success", "get-task should return code: success")
+			mctest.AssertEquals(t, value.RecordCount == 5, true, "get-task-count should be = 5")
+			mctest.AssertEquals(t, value.NextCursor != "", true, "next-cursor should be specified")
+			mctest.AssertEquals(t, value.PrevCursor, "", "prev-cursor should be empty, for the first page")
+			// next-page
+			getCrud.Cursor = value.NextCursor
+			nextRes := getCrud.GetAll(GetTableFields, tableFieldPointers)
+			nextValue, _ := nextRes.Value.(types.CrudResultType)
+			getCrud.CursorPaging = false
+			getCrud.Cursor = ""
+			getCrud.SortItems = nil
+			fmt.Printf("get-by-cursor-count: %v\n", nextValue.RecordCount)
+			mctest.AssertEquals(t, nextRes.Code, "success", "get-task should return code: success")
+			mctest.AssertEquals(t, nextValue.RecordCount > 0, true, "get-task-count should be > 0")
+			mctest.AssertEquals(t, nextValue.PrevCursor != "", true, "prev-cursor should be specified")
+			lastRec, _ := value.TableRecords[len(value.TableRecords)-1].(map[string]interface{})
+			firstNextRec, _ := nextValue.TableRecords[0].(map[string]interface{})
+			mctest.AssertEquals(t, lastRec["id"] != firstNextRec["id"], true, "next-page should not repeat the last record")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should stream all records and return success:",
+		TestFunc: func() {
+			var (
+				id            string
+				tableName     string
+				logRecords    interface{}
+				newLogRecords interface{}
+				logBy         string
+				logType       string
+				logAt         time.Time
+			)
+			getCrud.Skip = 0
+			getCrud.Limit = 20
+			getCrud.FetchSize = 6
+			tableFieldPointers := []interface{}{&id, &tableName, &logRecords, &newLogRecords, &logBy, &logType, &logAt}
+			res := getCrud.GetStream(context.Background(), GetTableFields, tableFieldPointers)
+			mctest.AssertEquals(t, res.Code, "success", "get-stream-task should return code: success")
+			stream, _ := res.Value.(types.RecordStreamType)
+			recCount := 0
+			var streamErr error
+			for rec := range stream.Records {
+				if rec.Err != nil {
+					streamErr = rec.Err
+					continue
+				}
+				recCount += 1
+			}
+			getCrud.FetchSize = 1000
+			fmt.Printf("get-stream-count: %v\n", recCount)
+			mctest.AssertEquals(t, streamErr, nil, "get-stream-error should be nil")
+			mctest.AssertEquals(t, recCount == 20, true, "get-stream-count should be = 20")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should stop the abandoned stream and release the pool connection:",
+		TestFunc: func() {
+			var (
+				id            string
+				tableName     string
+				logRecords    interface{}
+				newLogRecords interface{}
+				logBy         string
+				logType       string
+				logAt         time.Time
+			)
+			getCrud.Skip = 0
+			getCrud.Limit = 20
+			getCrud.FetchSize = 6
+			tableFieldPointers := []interface{}{&id, &tableName, &logRecords, &newLogRecords, &logBy, &logType, &logAt}
+			res := getCrud.GetStream(context.Background(), GetTableFields, tableFieldPointers)
+			getCrud.FetchSize = 1000
+			mctest.AssertEquals(t, res.Code, "success", "get-stream-task should return code: success")
+			stream, _ := res.Value.(types.RecordStreamType)
+			// receive the first record only, then abandon the stream
+			<-stream.Records
+			mctest.AssertEquals(t, dbc.DbConn.Stat().AcquiredConns(), int32(1), "stream should hold a pool connection")
+			stream.Stop()
+			// the stream go-routine closes the channel and releases the connection
+			for range stream.Records {
+			}
+			deadline := time.Now().Add(time.Second)
+			for dbc.DbConn.Stat().AcquiredConns() > 0 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			mctest.AssertEquals(t, dbc.DbConn.Stat().AcquiredConns(), int32(0), "stream should release the pool connection")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return readError for include-relations not specified in the table model:",
+		TestFunc: func() {
+			var (
+				id            string
+				tableName     string
+				logRecords    interface{}
+				newLogRecords interface{}
+				logBy         string
+				logType       string
+				logAt         time.Time
+			)
+			relCrudParams := getCrudParams
+			relCrudParams.IncludeRelations = []string{"users"}
+			relCrudOptions := TestCrudParamOptions
+			relCrudOptions.Model = &mctypes.ModelType{TableName: TestTable}
+			relCrud := NewCrud(relCrudParams, relCrudOptions)
+			tableFieldPointers := []interface{}{&id, &tableName, &logRecords, &newLogRecords, &logBy, &logType, &logAt}
+			res := relCrud.GetById(GetTableFields, tableFieldPointers)
+			mctest.AssertEquals(t, res.Code, "readError", "get-task should return code: readError")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should count records by Ids and return success:",
+		TestFunc: func() {
+			getCrud.RecordIds = GetIds
+			res := getCrud.Count()
+			value, _ := res.Value.(types.CrudResultType)
+			fmt.Printf("count-by-ids: %v\n", value.RecordCount)
+			mctest.AssertEquals(t, res.Code, "success", "count-task should return code: success")
+			mctest.AssertEquals(t, value.RecordCount, 2, "count-task-count should be: 2")
+			res = getCrud.Exists()
+			mctest.AssertEquals(t, res.Code, "success", "exists-task should return code: success")
+			mctest.AssertEquals(t, res.Value, true, "exists-task value should be: true")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should aggregate records by log_type and return success:",
+		TestFunc: func() {
+			getCrud.RecordIds = []string{}
+			getCrud.QueryParams = types.QueryParamType{}
+			res := getCrud.Aggregate(types.AggregateParamsType{
+				Items: []types.AggregateItemType{
+					{Function: types.AggregateCount},
+					{Function: types.AggregateMax, FieldName: "log_at"},
+				},
+				GroupBy: []string{"log_type"},
+				Having: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{{GroupItem: map[string]map[string]interface{}{"count": {"gte": 1}}}}},
+				},
+			})
+			getCrud.QueryParams = GetParams
+			value, _ := res.Value.(types.CrudResultType)
+			fmt.Printf("aggregate-records: %#v\n", value.TableRecords)
+			mctest.AssertEquals(t, res.Code, "success", "aggregate-task should return code: success")
+			mctest.AssertEquals(t, value.RecordCount > 0, true, "aggregate-task-count should be > 0")
+		},
+	})
+
 	mctest.McTest(mctest.OptionValue{
 		Name: "should get records by Id and return success[get-record method]:",
 		TestFunc: func() {
@@ -4,12 +4,13 @@ go 1.15
 
 require (
 	github.com/abbeymart/mcauditlog v0.3.4
-	github.com/abbeymart/mccache v0.3.1
 	github.com/abbeymart/mcdb v0.2.6
 	github.com/abbeymart/mcresponse v0.4.2
 	github.com/abbeymart/mctest v0.5.3
 	github.com/abbeymart/mctypes v0.4.4
 	github.com/abbeymart/mcutils v0.1.4 // indirect
 	github.com/asaskevich/govalidator v0.0.0-20200907205600-7a23bdc65eef
+	github.com/jackc/pgtype v1.6.2
 	github.com/jackc/pgx/v4 v4.10.1
+	go.mongodb.org/mongo-driver v1.4.4
 )
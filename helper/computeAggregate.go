@@ -0,0 +1,183 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute count, exists and aggregate (group-by/having) SQL scripts
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"strings"
+)
+
+// computeOptionalWhere function computes the where-script, if the where-params is specified (i.e. all records, otherwise)
+func computeOptionalWhere(where types.QueryParamType) (types.WhereQueryResponseType, error) {
+	if len(where) < 1 {
+		return types.WhereQueryResponseType{}, nil
+	}
+	whereRes, err := ComputeWhereQuery(where, 0)
+	if err != nil {
+		return types.WhereQueryResponseType{}, errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
+	}
+	return whereRes, nil
+}
+
+// ComputeCountQuery function computes the count script for the records that met the where-params (all records, if not specified)
+func ComputeCountQuery(tableName string, where types.QueryParamType) (types.SelectQueryResponseType, error) {
+	if tableName == "" {
+		return types.SelectQueryResponseType{}, errors.New("table-name is required to perform the count operation")
+	}
+	whereRes, err := computeOptionalWhere(where)
+	if err != nil {
+		return types.SelectQueryResponseType{}, err
+	}
+	return types.SelectQueryResponseType{
+		SelectQuery: strings.TrimSpace(fmt.Sprintf("SELECT COUNT(*) FROM %v %v", tableName, whereRes.WhereQuery)),
+		WhereQuery:  whereRes.WhereQuery,
+		FieldValues: whereRes.FieldValues,
+	}, nil
+}
+
+// ComputeExistsQuery function computes the exists script, i.e. any record met the where-params (any record, if not specified)
+func ComputeExistsQuery(tableName string, where types.QueryParamType) (types.SelectQueryResponseType, error) {
+	if tableName == "" {
+		return types.SelectQueryResponseType{}, errors.New("table-name is required to perform the exists operation")
+	}
+	whereRes, err := computeOptionalWhere(where)
+	if err != nil {
+		return types.SelectQueryResponseType{}, err
+	}
+	return types.SelectQueryResponseType{
+		SelectQuery: fmt.Sprintf("SELECT EXISTS(%v)", strings.TrimSpace(fmt.Sprintf("SELECT 1 FROM %v %v", tableName, whereRes.WhereQuery))),
+		WhereQuery:  whereRes.WhereQuery,
+		FieldValues: whereRes.FieldValues,
+	}, nil
+}
+
+// computeAggregateItem function computes the aggregate-function script and the result field-name (alias)
+func computeAggregateItem(item types.AggregateItemType) (string, string, error) {
+	fieldName := item.FieldName
+	if fieldName != "" && fieldName != "*" && !IsValidFieldName(fieldName) {
+		return "", "", errors.New(fmt.Sprintf("invalid aggregate field-name [%v]", fieldName))
+	}
+	if (fieldName == "" || fieldName == "*") && strings.ToLower(item.Function) != types.AggregateCount {
+		return "", "", errors.New(fmt.Sprintf("aggregate field-name is required for the %v function", item.Function))
+	}
+	alias := item.Alias
+	var itemScript string
+	switch strings.ToLower(item.Function) {
+	case types.AggregateSum, types.AggregateAvg, types.AggregateMin, types.AggregateMax:
+		itemScript = fmt.Sprintf("%v(%v)", strings.ToUpper(item.Function), fieldName)
+		if alias == "" {
+			alias = strings.ToLower(item.Function) + "_" + fieldName
+		}
+	case types.AggregateCount:
+		if fieldName == "" || fieldName == "*" {
+			itemScript = "COUNT(*)"
+			if alias == "" {
+				alias = types.AggregateCount
+			}
+		} else {
+			itemScript = fmt.Sprintf("COUNT(%v)", fieldName)
+			if alias == "" {
+				alias = types.AggregateCount + "_" + fieldName
+			}
+		}
+	case types.AggregateCountDistinct:
+		itemScript = fmt.Sprintf("COUNT(DISTINCT %v)", fieldName)
+		if alias == "" {
+			alias = types.AggregateCountDistinct + "_" + fieldName
+		}
+	default:
+		return "", "", errors.New(fmt.Sprintf("unsupported aggregate function [%v]", item.Function))
+	}
+	if !IsValidFieldName(alias) {
+		return "", "", errors.New(fmt.Sprintf("invalid aggregate alias [%v]", alias))
+	}
+	return itemScript, alias, nil
+}
+
+// ComputeAggregateQuery function computes the aggregate script, for the records that met the where-params
+// (all records, if not specified), grouped by the GroupBy field-names and filtered by the Having conditions.
+// The Having field-names must be aggregate-items' aliases or GroupBy field-names.
+func ComputeAggregateQuery(tableName string, aggregate types.AggregateParamsType, where types.QueryParamType) (types.SelectQueryResponseType, error) {
+	if tableName == "" || len(aggregate.Items) < 1 {
+		return types.SelectQueryResponseType{}, errors.New("table-name and aggregate-items are required to perform the aggregate operation")
+	}
+	var selectItems []string
+	var resultFields []string
+	// aggregate alias => aggregate script, for the having conditions
+	aliasScripts := map[string]string{}
+	for _, fieldName := range aggregate.GroupBy {
+		if !IsValidFieldName(fieldName) {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("invalid group-by field-name [%v]", fieldName))
+		}
+		if ArrayStringContains(resultFields, fieldName) {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("group-by field-name [%v] must be specified only once", fieldName))
+		}
+		selectItems = append(selectItems, fieldName)
+		resultFields = append(resultFields, fieldName)
+	}
+	for _, item := range aggregate.Items {
+		itemScript, alias, err := computeAggregateItem(item)
+		if err != nil {
+			return types.SelectQueryResponseType{}, err
+		}
+		if ArrayStringContains(resultFields, alias) {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("aggregate alias [%v] must be unique", alias))
+		}
+		selectItems = append(selectItems, fmt.Sprintf("%v AS %v", itemScript, alias))
+		resultFields = append(resultFields, alias)
+		aliasScripts[alias] = itemScript
+	}
+	whereRes, err := computeOptionalWhere(where)
+	if err != nil {
+		return types.SelectQueryResponseType{}, err
+	}
+	aggregateQuery := fmt.Sprintf("SELECT %v FROM %v", strings.Join(selectItems, ", "), tableName)
+	if whereRes.WhereQuery != "" {
+		aggregateQuery += " " + whereRes.WhereQuery
+	}
+	fieldValues := whereRes.FieldValues
+	if len(aggregate.GroupBy) > 0 {
+		aggregateQuery += " GROUP BY " + strings.Join(aggregate.GroupBy, ", ")
+	}
+	if len(aggregate.Having) > 0 {
+		// the having field-names must be the aggregate aliases or the group-by field-names
+		havingScripts := map[string]string{}
+		for alias, itemScript := range aliasScripts {
+			havingScripts[alias] = itemScript
+		}
+		for _, fieldName := range aggregate.GroupBy {
+			havingScripts[fieldName] = fieldName
+		}
+		for _, group := range aggregate.Having {
+			for _, gItem := range group.GroupItems {
+				if gItem.Group != nil {
+					return types.SelectQueryResponseType{}, errors.New("nested groups are not supported in the having conditions")
+				}
+				for fieldName := range gItem.GroupItem {
+					if _, ok := havingScripts[fieldName]; !ok {
+						return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("having field-name [%v] must be an aggregate alias or group-by field-name", fieldName))
+					}
+				}
+			}
+		}
+		// compute the having aliases as the aggregate scripts (aliases are not permitted in having conditions)
+		havingRes, err := computeWhereQuery(aggregate.Having, len(fieldValues), havingScripts)
+		if err != nil {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("error computing having-query condition(s): %v", err.Error()))
+		}
+		aggregateQuery += " HAVING " + strings.TrimPrefix(havingRes.WhereQuery, "WHERE ")
+		fieldValues = append(fieldValues, havingRes.FieldValues...)
+	}
+	if len(aggregate.GroupBy) > 0 {
+		aggregateQuery += " ORDER BY " + strings.Join(aggregate.GroupBy, ", ")
+	}
+	return types.SelectQueryResponseType{
+		SelectQuery: aggregateQuery,
+		WhereQuery:  whereRes.WhereQuery,
+		FieldValues: fieldValues,
+	}, nil
+}
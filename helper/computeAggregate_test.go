@@ -0,0 +1,77 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute count, exists and aggregate (group-by/having) SQL scripts test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestComputeAggregateQuery(t *testing.T) {
+	testCases := []struct {
+		name        string
+		aggregate   types.AggregateParamsType
+		where       types.QueryParamType
+		selectQuery string
+		fieldValues []interface{}
+		isError     bool
+	}{
+		{
+			name: "should compute the having conditions, with the aggregate scripts for the aliases:",
+			aggregate: types.AggregateParamsType{
+				Items: []types.AggregateItemType{
+					{Function: types.AggregateCount},
+					{Function: types.AggregateMax, FieldName: "log_at"},
+				},
+				GroupBy: []string{"log_type"},
+				Having: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"count": {"gte": 1}}},
+						{GroupItemOrder: 1, GroupItem: map[string]map[string]interface{}{"log_type": {"neq": "read"}}},
+					}},
+				},
+			},
+			where: whereItemParams("table_name", "eq", "audits"),
+			selectQuery: "SELECT log_type, COUNT(*) AS count, MAX(log_at) AS max_log_at FROM audits_log WHERE (table_name=$1) " +
+				"GROUP BY log_type HAVING (COUNT(*)>=$2 AND log_type<>$3) ORDER BY log_type",
+			fieldValues: []interface{}{"audits", 1, "read"},
+		},
+		{
+			name: "should return an error for the having field-name, not an aggregate alias or group-by field-name:",
+			aggregate: types.AggregateParamsType{
+				Items:   []types.AggregateItemType{{Function: types.AggregateCount}},
+				GroupBy: []string{"log_type"},
+				Having: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"log_by": {"eq": "abi"}}},
+					}},
+				},
+			},
+			isError: true,
+		},
+		{
+			name: "should return an error for the invalid where field-name:",
+			aggregate: types.AggregateParamsType{
+				Items: []types.AggregateItemType{{Function: types.AggregateCount}},
+			},
+			where:   whereItemParams("COUNT(*)", "gte", 1),
+			isError: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := ComputeAggregateQuery("audits_log", tc.aggregate, tc.where)
+				mctest.AssertEquals(t, err != nil, tc.isError, "aggregate-query error should be as expected")
+				mctest.AssertEquals(t, res.SelectQuery, tc.selectQuery, "aggregate-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "aggregate-query values should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
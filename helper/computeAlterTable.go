@@ -1,22 +1,69 @@
-// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2020-12-08
+// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2026-10-18
 // @Company: mConnect.biz | @License: MIT
 // @Description: compute alter-table script
 
 package helper
 
 import (
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mctypes"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"strings"
 )
 
-func CreateAlterTableQuery(model mctypes.ModelType) (string, error)  {
+// computeCreateAlterTableScripts function computes the (non-destructive) create-table-if-not-exists, add-column-if-not-exists
+// and create-index-if-not-exists scripts, from the model and options (SoftDelete)
+func computeCreateAlterTableScripts(model mctypes.ModelType, options types.TableOptionsType) ([]string, error) {
+	scripts, err := computeCreateTableScripts(model, options, true)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := computeTableColumns(model, options)
+	if err != nil {
+		return nil, err
+	}
+	// add the new model column(s), for an existing table
+	var alterScripts []string
+	for _, column := range columns {
+		if column.PrimaryKey {
+			continue
+		}
+		alterScripts = append(alterScripts, fmt.Sprintf("ALTER TABLE %v ADD COLUMN IF NOT EXISTS %v", model.TableName, computeColumnScript(column)))
+	}
+	// create-table script, add-column scripts, create-index scripts
+	return append(append([]string{scripts[0]}, alterScripts...), scripts[1:]...), nil
+}
 
+// CreateAlterTableQuery function computes the scripts (separated by ";\n") to create the table, if it does not exist,
+// or to add the new model column(s) and index(es) to the existing table. Existing columns are not changed or dropped,
+// see SyncTable for the complete schema sync.
+func CreateAlterTableQuery(model mctypes.ModelType) (string, error) {
+	return CreateAlterTableQueryWithOptions(model, types.TableOptionsType{})
+}
 
-	return "", nil
+// CreateAlterTableQueryWithOptions function computes the create/alter-table scripts, see CreateAlterTableQuery, with
+// the table options (SoftDelete)
+func CreateAlterTableQueryWithOptions(model mctypes.ModelType, options types.TableOptionsType) (string, error) {
+	scripts, err := computeCreateAlterTableScripts(model, options)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(scripts, ";\n"), nil
 }
 
+// CreateAlterTable function creates the table, if it does not exist, or adds the new model column(s) and index(es)
+// to the existing table, in a transaction
 func CreateAlterTable(model mctypes.ModelType, appDb *pgxpool.Pool) error {
+	return CreateAlterTableWithOptions(model, appDb, types.TableOptionsType{})
+}
 
-
-	return nil
+// CreateAlterTableWithOptions function creates or alters the table, see CreateAlterTable, with the table options
+// (SoftDelete)
+func CreateAlterTableWithOptions(model mctypes.ModelType, appDb *pgxpool.Pool, options types.TableOptionsType) error {
+	scripts, err := computeCreateAlterTableScripts(model, options)
+	if err != nil {
+		return err
+	}
+	return execScripts(appDb, scripts)
 }
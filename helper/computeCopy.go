@@ -0,0 +1,159 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute bulk-create (COPY) record-source and column-type aware values
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// actionParamsSource is the record-source for the action-params' records
+type actionParamsSource struct {
+	actionParams types.ActionParamsType
+	index        int
+}
+
+// NewRecordSource function returns the record-source for the action-params' records, for the bulk (COPY) create
+func NewRecordSource(actionParams types.ActionParamsType) types.RecordSourceType {
+	return &actionParamsSource{actionParams: actionParams, index: -1}
+}
+
+func (source *actionParamsSource) Next() bool {
+	source.index++
+	return source.index < len(source.actionParams)
+}
+
+func (source *actionParamsSource) Record() (types.ActionParamType, error) {
+	return source.actionParams[source.index], nil
+}
+
+func (source *actionParamsSource) Err() error {
+	return nil
+}
+
+// copyFromSource is the pgx CopyFromSource for the record-source, with the values computed by the column-types
+type copyFromSource struct {
+	connInfo   *pgtype.ConnInfo
+	source     types.RecordSourceType
+	fieldNames []string
+	dataTypes  []*pgtype.DataType // nil, if the column-type is not registered, e.g. enum
+	recNum     int
+	err        error
+}
+
+func (copySource *copyFromSource) Next() bool {
+	if copySource.err != nil {
+		return false
+	}
+	copySource.recNum++
+	return copySource.source.Next()
+}
+
+func (copySource *copyFromSource) Values() ([]interface{}, error) {
+	rec, err := copySource.source.Record()
+	if err != nil {
+		copySource.err = errors.New(fmt.Sprintf("Record #%v: %v", copySource.recNum, err.Error()))
+		return nil, copySource.err
+	}
+	var recValues []interface{}
+	for fieldIndex, fieldName := range copySource.fieldNames {
+		fieldValue, ok := rec[fieldName]
+		if !ok {
+			copySource.err = errors.New(fmt.Sprintf("Record #%v [%#v]: required field_name[%v] is missing ", copySource.recNum, rec, fieldName))
+			return nil, copySource.err
+		}
+		copyValue, err := computeCopyValue(copySource.connInfo, copySource.dataTypes[fieldIndex], fieldName, fieldValue)
+		if err != nil {
+			copySource.err = errors.New(fmt.Sprintf("Record #%v: %v", copySource.recNum, err.Error()))
+			return nil, copySource.err
+		}
+		recValues = append(recValues, copyValue)
+	}
+	return recValues, nil
+}
+
+func (copySource *copyFromSource) Err() error {
+	if copySource.err != nil {
+		return copySource.err
+	}
+	return copySource.source.Err()
+}
+
+// computeCopyValue function returns the fieldValue as the (binary-encoded) column-type value, e.g. uuid, json/jsonb,
+// timestamp and numeric values from strings, maps and structs. String values are parsed as the column-type text
+// format or, for date/time columns, RFC3339 date-time.
+func computeCopyValue(connInfo *pgtype.ConnInfo, dataType *pgtype.DataType, fieldName string, fieldValue interface{}) (interface{}, error) {
+	if fieldValue == nil {
+		return nil, nil
+	}
+	if _, ok := fieldValue.(pgtype.BinaryEncoder); ok {
+		return fieldValue, nil
+	}
+	if dataType == nil {
+		return ComputeFieldValue(fieldName, fieldValue)
+	}
+	copyValue := pgtype.NewValue(dataType.Value)
+	if strValue, ok := fieldValue.(string); ok {
+		if decoder, ok := copyValue.(pgtype.TextDecoder); ok {
+			if err := decoder.DecodeText(connInfo, []byte(strValue)); err == nil {
+				return copyValue, nil
+			}
+		}
+		if timeValue, err := time.Parse(time.RFC3339Nano, strValue); err == nil {
+			fieldValue = timeValue
+		}
+	}
+	if err := copyValue.Set(fieldValue); err != nil {
+		return nil, errors.New(fmt.Sprintf("field_name: %v | value [%v] is not a valid %v value: %v", fieldName, fieldValue, dataType.Name, err.Error()))
+	}
+	return copyValue, nil
+}
+
+// ComputeCopySource function returns the pgx CopyFromSource for the record-source and the fieldNames, with the
+// values computed by the table column-types (information_schema udt_name), for the transaction connection
+func ComputeCopySource(tx pgx.Tx, tableName string, source types.RecordSourceType, fieldNames []string) (pgx.CopyFromSource, error) {
+	if !IsValidFieldName(tableName) || len(fieldNames) < 1 {
+		return nil, errors.New("valid table-name and field-names are required for the copy operation")
+	}
+	rows, err := tx.Query(context.Background(), "SELECT column_name, udt_name FROM information_schema.columns "+
+		"WHERE table_schema = ANY(current_schemas(true)) AND table_name = $1", tableName)
+	if err != nil {
+		return nil, err
+	}
+	columnTypes := map[string]string{}
+	for rows.Next() {
+		var columnName, udtName string
+		if err = rows.Scan(&columnName, &udtName); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		columnTypes[columnName] = udtName
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	connInfo := tx.Conn().ConnInfo()
+	var dataTypes []*pgtype.DataType
+	for _, fieldName := range fieldNames {
+		udtName, ok := columnTypes[fieldName]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("field_name[%v] is not a column of the table [%v]", fieldName, tableName))
+		}
+		dataType, _ := connInfo.DataTypeForName(udtName)
+		dataTypes = append(dataTypes, dataType)
+	}
+	return &copyFromSource{
+		connInfo:   connInfo,
+		source:     source,
+		fieldNames: fieldNames,
+		dataTypes:  dataTypes,
+	}, nil
+}
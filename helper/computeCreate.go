@@ -5,17 +5,16 @@
 package helper
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/abbeymart/mccrud/types"
-
-	//"github.com/abbeymart/mccrud"
-	"github.com/asaskevich/govalidator"
+	"sort"
 	"strings"
-	"time"
 )
 
+// maxQueryPlaceholders is the maximum number of value-placeholders/parameters permitted (by PostgreSQL) per query
+const maxQueryPlaceholders = 65535
+
 func errMessage(errMsg string) (types.CreateQueryResponseType, error) {
 	return types.CreateQueryResponseType{
 		CreateQuery: "",
@@ -24,12 +23,8 @@ func errMessage(errMsg string) (types.CreateQueryResponseType, error) {
 	}, errors.New(errMsg)
 }
 
-// ComputeCreateQuery function computes insert SQL scripts. It returns createScripts []string and err error
-func ComputeCreateQuery(tableName string, actionParams types.ActionParamsType, tableFields []string) ([]string, error) {
-	if tableName == "" || len(actionParams) < 1 {
-		return nil, errors.New("table-name, action-params and table-fields are required for the create operation")
-	}
-
+// ComputeCreateFields function returns the tableFields or, if not specified, the create-fields from the first record
+func ComputeCreateFields(actionParams types.ActionParamsType, tableFields []string) []string {
 	// compute tableFields from the first record, if len(tableFields) == 0
 	if len(tableFields) == 0 {
 		actRec := actionParams[0]
@@ -37,386 +32,176 @@ func ComputeCreateQuery(tableName string, actionParams types.ActionParamsType, t
 			tableFields = append(tableFields, fName)
 		}
 	}
+	return tableFields
+}
 
-	// declare slice variable for create/insert queries
-	var insertQuery []string
-
-	// compute create script for all the records in actionParams
-	var itemQuery = fmt.Sprintf("INSERT INTO %v(%v)", tableName, strings.Join(tableFields, ", "))
-
-	// compute create values from actionParams/records
-	// value-computation for each of the actionParams' records must match the tableFields
+// computeCreateValues function computes the placeholder-values for each of the actionParams' records.
+// Value-computation for each of the actionParams' records must match the tableFields, i.e. valid field-names
+func computeCreateValues(actionParams types.ActionParamsType, tableFields []string) ([][]interface{}, error) {
+	for _, fieldName := range tableFields {
+		if !IsValidFieldName(fieldName) {
+			return nil, errors.New(fmt.Sprintf("invalid field_name[%v]", fieldName))
+		}
+	}
+	var fValues [][]interface{} // fieldValues array of arrays of values
 	for recNum, rec := range actionParams {
 		// initial item-values-computation variables
-		var itemValues = " VALUES("
-		fieldLength := len(tableFields)
-		fieldCount := 0
+		var recFieldValues []interface{}
 		for _, fieldName := range tableFields {
 			fieldValue, ok := rec[fieldName]
-			// check for the required field in each record
+			// check for required field in each record
 			if !ok {
 				return nil, errors.New(fmt.Sprintf("Record #%v [%#v]: required field_name[%v] is missing ", recNum, rec, fieldName))
 			}
-			fieldCount += 1
 			// update recFieldValues by fieldValue-type
-			var currentFieldValue interface{}
-			switch fieldValue.(type) {
-			case time.Time:
-				if fVal, ok := fieldValue.(time.Time); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-				}
-			case string:
-				if fVal, ok := fieldValue.(string); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					if govalidator.IsJSON(fVal) {
-						if fValue, err := govalidator.ToJSON(fieldValue); err != nil {
-							return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-						} else {
-							currentFieldValue = "'" + fValue + "'"
-						}
-					} else {
-						currentFieldValue = "'" + fVal + "'"
-					}
-				}
-			case bool:
-				if fVal, ok := fieldValue.(bool); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int8:
-				if fVal, ok := fieldValue.(int8); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int16:
-				if fVal, ok := fieldValue.(int16); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int32:
-				if fVal, ok := fieldValue.(int32); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int64:
-				if fVal, ok := fieldValue.(int64); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int:
-				if fVal, ok := fieldValue.(int); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint8:
-				if fVal, ok := fieldValue.(uint8); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint16:
-				if fVal, ok := fieldValue.(uint16); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint32:
-				if fVal, ok := fieldValue.(uint32); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint64:
-				if fVal, ok := fieldValue.(uint64); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint:
-				if fVal, ok := fieldValue.(uint); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case float32:
-				if fVal, ok := fieldValue.(float32); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case float64:
-				if fVal, ok := fieldValue.(float64); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []string:
-				if fVal, ok := fieldValue.([]string); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []int:
-				if fVal, ok := fieldValue.([]int); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []float32:
-				if fVal, ok := fieldValue.([]float32); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []float64:
-				if fVal, ok := fieldValue.([]float64); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []struct{}:
-				if fVal, ok := fieldValue.([]struct{}); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			default:
-				// json-stringify fieldValue
-				if fVal, err := json.Marshal(fieldValue); err != nil {
-					return nil, errors.New(fmt.Sprintf("Unknown or Unsupported field-value type: %v", err.Error()))
-				} else {
-					currentFieldValue = "'" + string(fVal) + "'"
-				}
-			}
-			// add itemValue
-			itemValues += fmt.Sprintf("%v", currentFieldValue)
-			if fieldLength > 1 && fieldCount < fieldLength {
-				itemValues += ", "
+			currentFieldValue, err := ComputeFieldValue(fieldName, fieldValue)
+			if err != nil {
+				return nil, err
 			}
+			recFieldValues = append(recFieldValues, currentFieldValue)
 		}
-		// close itemValues for the current-record
-		itemValues += ")"
-		// update insertQuery with the recordItem, include return value(s)
-		insertQuery = append(insertQuery, itemQuery+itemValues+" RETURNING id")
-		// reset itemValues for the next record iteration
-		itemValues = " VALUES("
+		// update fieldValues
+		fValues = append(fValues, recFieldValues)
 	}
+	return fValues, nil
+}
+
+// ComputeCreateQuery function computes a multi-records insert SQL script, with value-placeholders, for all the
+// actionParams' records. FieldValues contains a single slice of the placeholder values, in placeholder order.
+func ComputeCreateQuery(tableName string, actionParams types.ActionParamsType, tableFields []string) (types.CreateQueryResponseType, error) {
+	if tableName == "" || len(actionParams) < 1 {
+		return errMessage("table-name, action-params and table-fields are required for the create operation")
+	}
+	tableFields = ComputeCreateFields(actionParams, tableFields)
+	fieldsLength := len(tableFields)
+	if fieldsLength*len(actionParams) > maxQueryPlaceholders {
+		return errMessage(fmt.Sprintf("too many values [%v] for a single create-query, maximum of %v", fieldsLength*len(actionParams), maxQueryPlaceholders))
+	}
+	recValues, err := computeCreateValues(actionParams, tableFields)
+	if err != nil {
+		return errMessage(err.Error())
+	}
+	// compute create script for all the records in actionParams
+	var itemValues []string
+	var fieldValues []interface{}
+	for _, recFieldValues := range recValues {
+		itemValues = append(itemValues, "("+ComputePlaceholders(len(fieldValues)+1, fieldsLength)+")")
+		fieldValues = append(fieldValues, recFieldValues...)
+	}
+	// include return value(s)
+	insertQuery := fmt.Sprintf("INSERT INTO %v(%v) VALUES %v RETURNING id", tableName, strings.Join(tableFields, ", "), strings.Join(itemValues, ", "))
 	// result
-	return insertQuery, nil
+	return types.CreateQueryResponseType{
+		CreateQuery: insertQuery,
+		FieldNames:  tableFields,
+		FieldValues: [][]interface{}{fieldValues},
+	}, nil
 }
 
-// ComputeCreateCopyQuery function computes insert SQL script. It returns createScripts []string, fieldNames []string and err error
+// ComputeCreateCopyQuery function computes insert SQL script, with value-placeholders ($1...$n), for a record.
+// FieldValues contains the placeholder values for each of the actionParams' records, in tableFields (FieldNames) order
 func ComputeCreateCopyQuery(tableName string, actionParams types.ActionParamsType, tableFields []string) (types.CreateQueryResponseType, error) {
 	if tableName == "" || len(actionParams) < 1 {
 		return errMessage("table-name and action-params are required for the create operation")
 	}
-	// compute tableFields from the first record, if len(tableFields) == 0
-	if len(tableFields) == 0 {
-		actRec := actionParams[0]
-		for fName := range actRec {
-			tableFields = append(tableFields, fName)
-		}
-	}
-
-	var insertQuery string
-	var fValues [][]interface{} // fieldValues array of arrays of values
-	// value-computation for each of the actionParams' records must match the tableFields
+	tableFields = ComputeCreateFields(actionParams, tableFields)
 	// compute create script for all the create-task, with value-placeholders
-	var itemQuery = fmt.Sprintf("INSERT INTO %v(", tableName)
-	var itemValuePlaceholder = " VALUES("
-	fieldsLength := len(tableFields)
-	fieldCount := 0
-	for fieldIndex, fieldName := range tableFields {
-		itemQuery += fmt.Sprintf(" %v", fieldName)
-		itemValuePlaceholder += fmt.Sprintf(" $%v", fieldIndex+1)
-		fieldCount += 1
-		if fieldsLength > 1 && fieldCount < fieldsLength {
-			itemQuery += ", "
-			itemValuePlaceholder += ", "
-		}
+	insertQuery := fmt.Sprintf("INSERT INTO %v(%v) VALUES(%v) RETURNING id", tableName, strings.Join(tableFields, ", "), ComputePlaceholders(1, len(tableFields)))
+	// compute create values from actionParams
+	fValues, err := computeCreateValues(actionParams, tableFields)
+	if err != nil {
+		return errMessage(err.Error())
 	}
-	// close item-script/value-placeholder
-	itemQuery += " )"
-	itemValuePlaceholder += " )"
-	// add/append item-script & value-placeholder to the createScripts
-	insertQuery = itemQuery + itemValuePlaceholder + " RETURNING id"
+	// result
+	return types.CreateQueryResponseType{
+		CreateQuery: insertQuery,
+		FieldNames:  tableFields,
+		FieldValues: fValues,
+	}, nil
+}
 
-	// compute create values from actionParams
+// ComputeUpsertQuery function computes the insert-on-conflict (upsert) SQL script, with value-placeholders, for each of
+// the actionParams' records. The upsert-fields are the tableFields or, if not specified, the record fields, including
+// the record id, if specified. On conflict (conflictFields, default: id), the updateFields (default: the upsert-fields,
+// except the conflictFields, id and created_at/created_by) are updated or, if doNothing, the record is skipped.
+// The script returns the id and inserted (true: inserted, false: updated) values of the inserted/updated record.
+func ComputeUpsertQuery(tableName string, actionParams types.ActionParamsType, tableFields []string, conflictFields []string, updateFields []string, doNothing bool) ([]types.UpsertQueryResponseType, error) {
+	if tableName == "" || len(actionParams) < 1 {
+		return nil, errors.New("table-name and action-params are required for the upsert operation")
+	}
+	if len(conflictFields) < 1 {
+		conflictFields = []string{"id"}
+	}
+	var upsertQuery []types.UpsertQueryResponseType
 	for recNum, rec := range actionParams {
-		// initial item-values-computation variables
-		var recFieldValues []interface{}
-		for _, fieldName := range tableFields {
-			fieldValue, ok := rec[fieldName]
-			// check for required field in each record
-			if !ok {
-				return errMessage(fmt.Sprintf("Record #%v [%#v]: required field_name[%v] has field_value of %v ", recNum, rec, fieldName, fieldValue))
+		// upsert-fields, sorted for a stable script
+		recFields := append([]string{}, tableFields...)
+		if len(recFields) < 1 {
+			for fieldName := range rec {
+				recFields = append(recFields, fieldName)
 			}
-			// update recFieldValues by fieldValue-type
-			var currentFieldValue interface{}
-			switch fieldValue.(type) {
-			case time.Time:
-				if fVal, ok := fieldValue.(time.Time); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-					//recFieldValues = append(recFieldValues, "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'")
-				}
-			case string:
-				if fVal, ok := fieldValue.(string); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					if govalidator.IsUUID(fVal) {
-						currentFieldValue = fVal
-					} else if govalidator.IsJSON(fVal) {
-						if fValue, err := govalidator.ToJSON(fieldValue); err != nil {
-							return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-						} else {
-							fmt.Printf("string-toJson-value: %v\n\n", fValue)
-							currentFieldValue = fValue
-							//recFieldValues = append(recFieldValues, "'" + fValue + "'")
-						}
-					} else {
-						currentFieldValue = "'" + fVal + "'"
-						//recFieldValues = append(recFieldValues, "'" + fVal + "'")
-					}
-				}
-			case bool:
-				if fVal, ok := fieldValue.(bool); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int8:
-				if fVal, ok := fieldValue.(int8); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int16:
-				if fVal, ok := fieldValue.(int16); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int32:
-				if fVal, ok := fieldValue.(int32); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int64:
-				if fVal, ok := fieldValue.(int64); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case int:
-				if fVal, ok := fieldValue.(int); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint8:
-				if fVal, ok := fieldValue.(uint8); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint16:
-				if fVal, ok := fieldValue.(uint16); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint32:
-				if fVal, ok := fieldValue.(uint32); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint64:
-				if fVal, ok := fieldValue.(uint64); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case uint:
-				if fVal, ok := fieldValue.(uint); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case float32:
-				if fVal, ok := fieldValue.(float32); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case float64:
-				if fVal, ok := fieldValue.(float64); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []string:
-				if fVal, ok := fieldValue.([]string); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []int:
-				if fVal, ok := fieldValue.([]int); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []float32:
-				if fVal, ok := fieldValue.([]float32); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []float64:
-				if fVal, ok := fieldValue.([]float64); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
-				}
-			case []struct{}:
-				if fVal, ok := fieldValue.([]struct{}); !ok {
-					return errMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = fVal
+			sort.Strings(recFields)
+		}
+		if recId, ok := rec["id"]; ok && recId != "" && recId != nil && !ArrayStringContains(recFields, "id") {
+			recFields = append(recFields, "id")
+		} else if !ok || recId == "" || recId == nil {
+			// new record: id default value
+			var fields []string
+			for _, fieldName := range recFields {
+				if fieldName != "id" {
+					fields = append(fields, fieldName)
 				}
-			default:
-				// json-stringify fieldValue
-				if fVal, err := json.Marshal(fieldValue); err != nil {
-					return errMessage(fmt.Sprintf("Unknown or Unsupported field-value type: %v", err.Error()))
-				} else {
-					currentFieldValue = string(fVal)
+			}
+			recFields = fields
+		}
+		for _, fieldName := range recFields {
+			if !IsValidFieldName(fieldName) {
+				return nil, errors.New(fmt.Sprintf("Record #%v: invalid field_name[%v]", recNum, fieldName))
+			}
+		}
+		recValues, err := computeCreateValues(types.ActionParamsType{rec}, recFields)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Record #%v: %v", recNum, err.Error()))
+		}
+		recUpdateFields := updateFields
+		if len(recUpdateFields) < 1 {
+			for _, fieldName := range recFields {
+				if fieldName != "id" && fieldName != "created_at" && fieldName != "created_by" && !ArrayStringContains(conflictFields, fieldName) {
+					recUpdateFields = append(recUpdateFields, fieldName)
 				}
 			}
-			// add itemValue
-			recFieldValues = append(recFieldValues, currentFieldValue)
 		}
-		// update fieldValues
-		fValues = append(fValues, recFieldValues)
-		// re-initialise recFieldValues, for next update
-		recFieldValues = []interface{}{}
+		onConflictQuery, err := ComputeOnConflictQuery(conflictFields, recUpdateFields, doNothing)
+		if err != nil {
+			return nil, err
+		}
+		upsertQuery = append(upsertQuery, types.UpsertQueryResponseType{
+			UpsertQuery: fmt.Sprintf("INSERT INTO %v(%v) VALUES(%v) %v RETURNING id, (xmax = 0) AS inserted", tableName, strings.Join(recFields, ", "), ComputePlaceholders(1, len(recFields)), onConflictQuery),
+			FieldNames:  recFields,
+			FieldValues: recValues[0],
+		})
 	}
-	// result
-	return types.CreateQueryResponseType{
-		CreateQuery: insertQuery,
-		FieldNames:  tableFields,
-		FieldValues: fValues,
-	}, nil
+	return upsertQuery, nil
+}
+
+// ComputeOnConflictQuery function computes the on-conflict script, for the conflictFields (conflict target):
+// DO NOTHING, if doNothing or no updateFields, otherwise DO UPDATE SET the updateFields to the insert (EXCLUDED) values
+func ComputeOnConflictQuery(conflictFields []string, updateFields []string, doNothing bool) (string, error) {
+	if len(conflictFields) < 1 {
+		return "", errors.New("conflict-fields are required for the on-conflict operation")
+	}
+	for _, fieldName := range append(append([]string{}, conflictFields...), updateFields...) {
+		if !IsValidFieldName(fieldName) {
+			return "", errors.New(fmt.Sprintf("invalid conflict/update field-name [%v]", fieldName))
+		}
+	}
+	onConflictQuery := fmt.Sprintf("ON CONFLICT (%v)", strings.Join(conflictFields, ", "))
+	if doNothing || len(updateFields) < 1 {
+		return onConflictQuery + " DO NOTHING", nil
+	}
+	var setItems []string
+	for _, fieldName := range updateFields {
+		setItems = append(setItems, fmt.Sprintf("%v = EXCLUDED.%v", fieldName, fieldName))
+	}
+	return onConflictQuery + " DO UPDATE SET " + strings.Join(setItems, ", "), nil
 }
@@ -1,21 +1,303 @@
-// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2020-12-08
+// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2026-10-18
 // @Company: mConnect.biz | @License: MIT
 // @Description: compute create-table script
 
 package helper
 
 import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/datatypes"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"sort"
+	"strings"
 )
-func CreateTableQuery(model mctypes.ModelType) (string, error)  {
 
+// tableColumnType is the (PostgreSQL) table-column description, computed from the model RecordDesc and stamps
+type tableColumnType struct {
+	Name       string
+	DataType   string
+	NotNull    bool
+	Unique     bool
+	PrimaryKey bool
+	Indexable  bool
+	Default    string
+}
+
+// default string/varchar field-length
+const defaultFieldLength = 255
+
+// ComputeColumnType function returns the PostgreSQL column-type for the model field-description (FieldType and FieldLength)
+func ComputeColumnType(fieldDesc mctypes.FieldDescType) (string, error) {
+	fieldLength := fieldDesc.FieldLength
+	if fieldLength <= 0 {
+		fieldLength = defaultFieldLength
+	}
+	switch strings.ToLower(fieldDesc.FieldType) {
+	case datatypes.String, datatypes.StringAlpha, datatypes.StringAlphaNumeric, datatypes.PostalCode, datatypes.Email,
+		datatypes.URL, datatypes.DomainName, datatypes.MD4, datatypes.MD5, datatypes.SHA1, datatypes.SHA256,
+		datatypes.SHA384, datatypes.SHA512, datatypes.IP, datatypes.IP4, datatypes.IP6, datatypes.ISO2, datatypes.ISO3,
+		datatypes.MACAddress, datatypes.Mime, datatypes.CreditCard, datatypes.Currency, datatypes.IMEI,
+		strings.ToLower(datatypes.LatitudeLongitude):
+		return fmt.Sprintf("VARCHAR(%v)", fieldLength), nil
+	case datatypes.Text, datatypes.JWT:
+		return "TEXT", nil
+	case datatypes.MongoDBId:
+		return "VARCHAR(24)", nil
+	case datatypes.UUID, datatypes.UUID3, datatypes.UUID4, datatypes.UUID5:
+		return "UUID", nil
+	case datatypes.Integer, datatypes.Positive, datatypes.Natural, datatypes.Negative, datatypes.Port:
+		return "INTEGER", nil
+	case datatypes.BigInt:
+		return "BIGINT", nil
+	case datatypes.Number, datatypes.Decimal, datatypes.BigFloat:
+		return "NUMERIC", nil
+	case datatypes.Float32:
+		return "REAL", nil
+	case datatypes.Float, datatypes.Float64, datatypes.Latitude, datatypes.Longitude:
+		return "DOUBLE PRECISION", nil
+	case datatypes.Boolean:
+		return "BOOLEAN", nil
+	case datatypes.JSON, datatypes.Object, datatypes.Map, datatypes.Set, datatypes.Array, datatypes.ArrayOfStruct,
+		datatypes.ArrayOfMap, datatypes.ArrayOfArray:
+		return "JSONB", nil
+	case datatypes.ArrayOfString:
+		return "TEXT[]", nil
+	case datatypes.ArrayOfNumber:
+		return "NUMERIC[]", nil
+	case datatypes.ArrayOfBoolean:
+		return "BOOLEAN[]", nil
+	case datatypes.DateTime, datatypes.TimeStampZ:
+		return "TIMESTAMPTZ", nil
+	case datatypes.TimeStamp:
+		return "TIMESTAMP", nil
+	case datatypes.Date:
+		return "DATE", nil
+	case datatypes.Time:
+		return "TIME", nil
+	default:
+		return "", errors.New(fmt.Sprintf("unsupported field-type [%v]", fieldDesc.FieldType))
+	}
+}
+
+// computeTableColumns function computes the table-columns from the model RecordDesc, in the order: id,
+// the other fields (sorted by field-name), and the time (created_at, updated_at), actor (created_by, updated_by)
+// and active (is_active) stamps, if specified, and the soft-delete columns (deleted_at, deleted_by), for the
+// SoftDelete option. The id field (UUID, default: gen_random_uuid()) is added,
+// if not specified, and it is the primary key, if no other field is specified as the primary key.
+// gen_random_uuid() requires PostgreSQL 13+ or the pgcrypto extension.
+func computeTableColumns(model mctypes.ModelType, options types.TableOptionsType) ([]tableColumnType, error) {
+	if model.TableName == "" || len(model.RecordDesc) < 1 {
+		return nil, errors.New("table-name and record-description are required to compute the table-columns")
+	}
+	if !IsValidFieldName(model.TableName) {
+		return nil, errors.New(fmt.Sprintf("invalid table-name [%v]", model.TableName))
+	}
+	var fieldNames []string
+	hasPrimaryKey := false
+	for fieldName, fieldDesc := range model.RecordDesc {
+		if !IsValidFieldName(fieldName) {
+			return nil, errors.New(fmt.Sprintf("invalid field-name [%v]", fieldName))
+		}
+		if fieldName != "id" {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		if fieldDesc.PrimaryKey {
+			hasPrimaryKey = true
+		}
+	}
+	sort.Strings(fieldNames)
+	var columns []tableColumnType
+	// id field
+	if idDesc, ok := model.RecordDesc["id"]; ok {
+		dataType, err := ComputeColumnType(idDesc)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("field-name [id]: %v", err.Error()))
+		}
+		idColumn := tableColumnType{
+			Name:       "id",
+			DataType:   dataType,
+			NotNull:    true,
+			Unique:     idDesc.Unique && hasPrimaryKey && !idDesc.PrimaryKey,
+			PrimaryKey: idDesc.PrimaryKey || !hasPrimaryKey,
+			Indexable:  idDesc.Indexable,
+		}
+		if dataType == "UUID" {
+			idColumn.Default = "gen_random_uuid()"
+		}
+		columns = append(columns, idColumn)
+	} else {
+		columns = append(columns, tableColumnType{
+			Name:       "id",
+			DataType:   "UUID",
+			NotNull:    true,
+			Unique:     hasPrimaryKey,
+			PrimaryKey: !hasPrimaryKey,
+			Default:    "gen_random_uuid()",
+		})
+	}
+	for _, fieldName := range fieldNames {
+		fieldDesc := model.RecordDesc[fieldName]
+		dataType, err := ComputeColumnType(fieldDesc)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("field-name [%v]: %v", fieldName, err.Error()))
+		}
+		columns = append(columns, tableColumnType{
+			Name:       fieldName,
+			DataType:   dataType,
+			NotNull:    !fieldDesc.AllowNull || fieldDesc.PrimaryKey,
+			Unique:     fieldDesc.Unique && !fieldDesc.PrimaryKey,
+			PrimaryKey: fieldDesc.PrimaryKey,
+			Indexable:  fieldDesc.Indexable,
+		})
+	}
+	// stamp columns, if not specified in the RecordDesc
+	var stampColumns []tableColumnType
+	if model.TimeStamp {
+		stampColumns = append(stampColumns,
+			tableColumnType{Name: "created_at", DataType: "TIMESTAMPTZ", NotNull: true, Default: "CURRENT_TIMESTAMP"},
+			tableColumnType{Name: "updated_at", DataType: "TIMESTAMPTZ", NotNull: true, Default: "CURRENT_TIMESTAMP"},
+		)
+	}
+	if model.ActorStamp {
+		stampColumns = append(stampColumns,
+			tableColumnType{Name: "created_by", DataType: fmt.Sprintf("VARCHAR(%v)", defaultFieldLength)},
+			tableColumnType{Name: "updated_by", DataType: fmt.Sprintf("VARCHAR(%v)", defaultFieldLength)},
+		)
+	}
+	if model.ActiveStamp {
+		stampColumns = append(stampColumns,
+			tableColumnType{Name: "is_active", DataType: "BOOLEAN", NotNull: true, Default: "TRUE"},
+		)
+	}
+	if options.SoftDelete {
+		stampColumns = append(stampColumns,
+			tableColumnType{Name: types.DeletedAtField, DataType: "TIMESTAMPTZ"},
+			tableColumnType{Name: types.DeletedByField, DataType: fmt.Sprintf("VARCHAR(%v)", defaultFieldLength)},
+		)
+	}
+	for _, column := range stampColumns {
+		if _, ok := model.RecordDesc[column.Name]; !ok {
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// computeColumnScript function computes the column-definition script, excluding the primary key constraint
+func computeColumnScript(column tableColumnType) string {
+	columnScript := column.Name + " " + column.DataType
+	if column.NotNull {
+		columnScript += " NOT NULL"
+	}
+	if column.Default != "" {
+		columnScript += " DEFAULT " + column.Default
+	}
+	if column.Unique {
+		columnScript += " UNIQUE"
+	}
+	return columnScript
+}
+
+// maxIdentifierLength is the PostgreSQL identifier (e.g. index-name) maximum length, in bytes, longer names are truncated
+const maxIdentifierLength = 63
+
+// computeIdentifierName function returns the name, capped at the maxIdentifierLength, i.e. the truncated name with the
+// name hash suffix, for the longer names, that would be truncated (and may collide), by PostgreSQL
+func computeIdentifierName(name string) string {
+	if len(name) <= maxIdentifierLength {
+		return name
+	}
+	hashSuffix := fmt.Sprintf("_%x", sha256.Sum256([]byte(name)))[:9]
+	return name[:maxIdentifierLength-len(hashSuffix)] + hashSuffix
+}
 
-	return "", nil
+// computeIndexName function returns the (non-unique) index-name for the table-field, see computeIdentifierName
+func computeIndexName(tableName string, fieldName string) string {
+	return computeIdentifierName(fmt.Sprintf("%v_%v_idx", tableName, fieldName))
 }
 
+// computeIndexScript function computes the create-index script for the table-field
+func computeIndexScript(tableName string, fieldName string) string {
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %v ON %v (%v)", computeIndexName(tableName, fieldName), tableName, fieldName)
+}
+
+// computeCreateTableScripts function computes the create-table script, followed by the create-index scripts
+func computeCreateTableScripts(model mctypes.ModelType, options types.TableOptionsType, ifNotExists bool) ([]string, error) {
+	columns, err := computeTableColumns(model, options)
+	if err != nil {
+		return nil, err
+	}
+	var columnScripts []string
+	var primaryKeys []string
+	var indexScripts []string
+	for _, column := range columns {
+		columnScripts = append(columnScripts, computeColumnScript(column))
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, column.Name)
+		}
+		// primary key and unique columns are indexed by the constraints
+		if column.Indexable && !column.PrimaryKey && !column.Unique {
+			indexScripts = append(indexScripts, computeIndexScript(model.TableName, column.Name))
+		}
+	}
+	columnScripts = append(columnScripts, fmt.Sprintf("PRIMARY KEY (%v)", strings.Join(primaryKeys, ", ")))
+	createScript := "CREATE TABLE "
+	if ifNotExists {
+		createScript += "IF NOT EXISTS "
+	}
+	createScript += fmt.Sprintf("%v (%v)", model.TableName, strings.Join(columnScripts, ", "))
+	return append([]string{createScript}, indexScripts...), nil
+}
+
+// CreateTableQuery function computes the create-table and create-index scripts (separated by ";\n")
+// from the model RecordDesc and stamps (TimeStamp, ActorStamp and ActiveStamp)
+func CreateTableQuery(model mctypes.ModelType) (string, error) {
+	return CreateTableQueryWithOptions(model, types.TableOptionsType{})
+}
+
+// CreateTableQueryWithOptions function computes the create-table and create-index scripts, see CreateTableQuery,
+// with the table options (SoftDelete)
+func CreateTableQueryWithOptions(model mctypes.ModelType, options types.TableOptionsType) (string, error) {
+	scripts, err := computeCreateTableScripts(model, options, false)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(scripts, ";\n"), nil
+}
+
+// CreateTable function creates the table and indexes, from the model, in a transaction
 func CreateTable(model mctypes.ModelType, appDb *pgxpool.Pool) error {
+	return CreateTableWithOptions(model, appDb, types.TableOptionsType{})
+}
 
+// CreateTableWithOptions function creates the table and indexes, see CreateTable, with the table options (SoftDelete)
+func CreateTableWithOptions(model mctypes.ModelType, appDb *pgxpool.Pool, options types.TableOptionsType) error {
+	scripts, err := computeCreateTableScripts(model, options, false)
+	if err != nil {
+		return err
+	}
+	return execScripts(appDb, scripts)
+}
 
-	return nil
-}
\ No newline at end of file
+// execScripts function executes the (DDL) scripts, in order, in a transaction
+func execScripts(appDb *pgxpool.Pool, scripts []string) error {
+	if appDb == nil {
+		return errors.New("db connection is required to execute the table scripts")
+	}
+	tx, txErr := appDb.Begin(context.Background())
+	if txErr != nil {
+		return txErr
+	}
+	defer tx.Rollback(context.Background())
+	for _, script := range scripts {
+		if _, err := tx.Exec(context.Background(), script); err != nil {
+			return errors.New(fmt.Sprintf("error executing script [%v]: %v", script, err.Error()))
+		}
+	}
+	return tx.Commit(context.Background())
+}
@@ -0,0 +1,104 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute create-table script test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/datatypes"
+	"testing"
+)
+
+func TestCreateTableQuery(t *testing.T) {
+	testCases := []struct {
+		name        string
+		model       mctypes.ModelType
+		options     types.TableOptionsType
+		createQuery string
+		isError     bool
+	}{
+		{
+			name: "should compute the create-table script, with the default id primary key:",
+			model: mctypes.ModelType{
+				TableName: "users",
+				RecordDesc: mctypes.RecordDescType{
+					"name": {FieldType: datatypes.String, FieldLength: 100},
+					"age":  {FieldType: datatypes.Integer, AllowNull: true},
+				},
+			},
+			createQuery: "CREATE TABLE users (id UUID NOT NULL DEFAULT gen_random_uuid(), age INTEGER, " +
+				"name VARCHAR(100) NOT NULL, PRIMARY KEY (id))",
+		},
+		{
+			name: "should compute the create-table script, with the unique and index fields and the stamps:",
+			model: mctypes.ModelType{
+				TableName: "users",
+				RecordDesc: mctypes.RecordDescType{
+					"email":   {FieldType: datatypes.Email, Unique: true},
+					"profile": {FieldType: datatypes.JSON, AllowNull: true},
+					"city":    {FieldType: datatypes.String, Indexable: true},
+				},
+				TimeStamp:   true,
+				ActorStamp:  true,
+				ActiveStamp: true,
+			},
+			options: types.TableOptionsType{SoftDelete: true},
+			createQuery: "CREATE TABLE users (id UUID NOT NULL DEFAULT gen_random_uuid(), city VARCHAR(255) NOT NULL, " +
+				"email VARCHAR(255) NOT NULL UNIQUE, profile JSONB, " +
+				"created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, " +
+				"created_by VARCHAR(255), updated_by VARCHAR(255), is_active BOOLEAN NOT NULL DEFAULT TRUE, " +
+				"deleted_at TIMESTAMPTZ, deleted_by VARCHAR(255), PRIMARY KEY (id));\n" +
+				"CREATE INDEX IF NOT EXISTS users_city_idx ON users (city)",
+		},
+		{
+			name: "should compute the create-table script, with the model primary key and the unique id:",
+			model: mctypes.ModelType{
+				TableName: "codes",
+				RecordDesc: mctypes.RecordDescType{
+					"code": {FieldType: datatypes.String, FieldLength: 10, PrimaryKey: true},
+				},
+			},
+			createQuery: "CREATE TABLE codes (id UUID NOT NULL DEFAULT gen_random_uuid() UNIQUE, " +
+				"code VARCHAR(10) NOT NULL, PRIMARY KEY (code))",
+		},
+		{
+			name: "should return an error for the invalid field-name:",
+			model: mctypes.ModelType{
+				TableName: "users",
+				RecordDesc: mctypes.RecordDescType{
+					"name text); DROP TABLE users; --": {FieldType: datatypes.String},
+				},
+			},
+			isError: true,
+		},
+		{
+			name: "should return an error for the unsupported field-type:",
+			model: mctypes.ModelType{
+				TableName: "users",
+				RecordDesc: mctypes.RecordDescType{
+					"name": {FieldType: "unknown"},
+				},
+			},
+			isError: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				createQuery, err := CreateTableQueryWithOptions(tc.model, tc.options)
+				mctest.AssertEquals(t, err != nil, tc.isError, "create-table error should be as expected")
+				mctest.AssertEquals(t, createQuery, tc.createQuery, "create-table script should be as expected")
+				if !tc.options.SoftDelete {
+					defaultQuery, _ := CreateTableQuery(tc.model)
+					mctest.AssertEquals(t, defaultQuery, tc.createQuery, "create-table script, without the options, should be as expected")
+				}
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
@@ -0,0 +1,58 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute create, create-copy and upsert SQL scripts test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestComputeCreateQuery(t *testing.T) {
+	testCases := []struct {
+		name         string
+		actionParams types.ActionParamsType
+		tableFields  []string
+		createQuery  string
+		fieldValues  [][]interface{}
+		isError      bool
+	}{
+		{
+			name:         "should compute the multi-records create-query, in the tableFields order:",
+			actionParams: types.ActionParamsType{{"name": "abi", "age": 30}, {"name": "ola", "age": 25}},
+			tableFields:  []string{"name", "age"},
+			createQuery:  "INSERT INTO users(name, age) VALUES ($1, $2), ($3, $4) RETURNING id",
+			fieldValues:  [][]interface{}{{"abi", 30, "ola", 25}},
+		},
+		{
+			name:         "should return an error for the injected tableFields field-name:",
+			actionParams: types.ActionParamsType{{"name": "abi", "age) VALUES (1); DROP TABLE users; --": 30}},
+			tableFields:  []string{"name", "age) VALUES (1); DROP TABLE users; --"},
+			isError:      true,
+		},
+		{
+			name:         "should return an error for the injected record field-name, without the tableFields:",
+			actionParams: types.ActionParamsType{{"name": "abi", "age) VALUES (1); --": 30}},
+			isError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := ComputeCreateQuery("users", tc.actionParams, tc.tableFields)
+				mctest.AssertEquals(t, err != nil, tc.isError, "create-query error should be as expected")
+				mctest.AssertEquals(t, res.CreateQuery, tc.createQuery, "create-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "create-query values should be as expected")
+				_, copyErr := ComputeCreateCopyQuery("users", tc.actionParams, tc.tableFields)
+				mctest.AssertEquals(t, copyErr != nil, tc.isError, "create-copy-query error should be as expected")
+				_, upsertErr := ComputeUpsertQuery("users", tc.actionParams, tc.tableFields, nil, nil, false)
+				mctest.AssertEquals(t, upsertErr != nil, tc.isError, "upsert-query error should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
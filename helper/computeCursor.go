@@ -0,0 +1,194 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute keyset/cursor-paging scripts and encode/decode the (opaque) paging cursors
+
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cursorValueType is the type-tagged cursor value, to restore the sort-field value-type on decode
+type cursorValueType struct {
+	Type  string `json:"t"`
+	Value string `json:"v"`
+}
+
+type cursorInfoType struct {
+	Direction  string            `json:"d"`
+	FieldNames []string          `json:"f"`
+	SortOrders []int             `json:"o"`
+	Values     []cursorValueType `json:"v"`
+}
+
+// encodeCursorValue function transforms the sort-field value into the type-tagged cursor value
+func encodeCursorValue(fieldName string, fieldValue interface{}) (cursorValueType, error) {
+	switch val := fieldValue.(type) {
+	case time.Time:
+		return cursorValueType{Type: "time", Value: val.Format(time.RFC3339Nano)}, nil
+	case string:
+		return cursorValueType{Type: "string", Value: val}, nil
+	case bool:
+		return cursorValueType{Type: "bool", Value: strconv.FormatBool(val)}, nil
+	case int:
+		return cursorValueType{Type: "int", Value: strconv.FormatInt(int64(val), 10)}, nil
+	case int16:
+		return cursorValueType{Type: "int", Value: strconv.FormatInt(int64(val), 10)}, nil
+	case int32:
+		return cursorValueType{Type: "int", Value: strconv.FormatInt(int64(val), 10)}, nil
+	case int64:
+		return cursorValueType{Type: "int", Value: strconv.FormatInt(val, 10)}, nil
+	case float32:
+		return cursorValueType{Type: "float", Value: strconv.FormatFloat(float64(val), 'g', -1, 32)}, nil
+	case float64:
+		return cursorValueType{Type: "float", Value: strconv.FormatFloat(val, 'g', -1, 64)}, nil
+	case [16]byte:
+		// uuid
+		return cursorValueType{Type: "string", Value: fmt.Sprintf("%x-%x-%x-%x-%x", val[0:4], val[4:6], val[6:8], val[8:10], val[10:16])}, nil
+	case nil:
+		return cursorValueType{}, errors.New(fmt.Sprintf("cursor-paging sort field-name [%v] value must not be null", fieldName))
+	default:
+		return cursorValueType{}, errors.New(fmt.Sprintf("cursor-paging sort field-name [%v] value-type [%T] is not supported", fieldName, fieldValue))
+	}
+}
+
+// decodeCursorValue function transforms the type-tagged cursor value into the sort-field value
+func decodeCursorValue(cursorValue cursorValueType) (interface{}, error) {
+	switch cursorValue.Type {
+	case "time":
+		return time.Parse(time.RFC3339Nano, cursorValue.Value)
+	case "string":
+		return cursorValue.Value, nil
+	case "bool":
+		return strconv.ParseBool(cursorValue.Value)
+	case "int":
+		return strconv.ParseInt(cursorValue.Value, 10, 64)
+	case "float":
+		return strconv.ParseFloat(cursorValue.Value, 64)
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid cursor value-type [%v]", cursorValue.Type))
+	}
+}
+
+// EncodeCursor function encodes the cursor information into an opaque (url-safe) cursor string
+func EncodeCursor(cursor types.CursorType) (string, error) {
+	if cursor.Direction != types.CursorNext && cursor.Direction != types.CursorPrev {
+		return "", errors.New(fmt.Sprintf("cursor direction must be %v or %v", types.CursorNext, types.CursorPrev))
+	}
+	if len(cursor.FieldNames) < 1 || len(cursor.FieldNames) != len(cursor.Values) || len(cursor.FieldNames) != len(cursor.SortOrders) {
+		return "", errors.New("cursor field-names, sort-orders and values are required and must be of the same length")
+	}
+	cursorInfo := cursorInfoType{
+		Direction:  cursor.Direction,
+		FieldNames: cursor.FieldNames,
+		SortOrders: cursor.SortOrders,
+	}
+	for i, fieldValue := range cursor.Values {
+		cursorValue, err := encodeCursorValue(cursor.FieldNames[i], fieldValue)
+		if err != nil {
+			return "", err
+		}
+		cursorInfo.Values = append(cursorInfo.Values, cursorValue)
+	}
+	cByte, err := json.Marshal(cursorInfo)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(cByte), nil
+}
+
+// DecodeCursor function decodes the opaque cursor string, from EncodeCursor, into the cursor information
+func DecodeCursor(cursor string) (types.CursorType, error) {
+	cByte, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return types.CursorType{}, errors.New("invalid cursor")
+	}
+	var cursorInfo cursorInfoType
+	if err = json.Unmarshal(cByte, &cursorInfo); err != nil {
+		return types.CursorType{}, errors.New("invalid cursor")
+	}
+	if cursorInfo.Direction != types.CursorNext && cursorInfo.Direction != types.CursorPrev {
+		return types.CursorType{}, errors.New("invalid cursor direction")
+	}
+	if len(cursorInfo.FieldNames) < 1 || len(cursorInfo.FieldNames) != len(cursorInfo.Values) || len(cursorInfo.FieldNames) != len(cursorInfo.SortOrders) {
+		return types.CursorType{}, errors.New("invalid cursor field-names, sort-orders or values")
+	}
+	result := types.CursorType{
+		Direction:  cursorInfo.Direction,
+		FieldNames: cursorInfo.FieldNames,
+		SortOrders: cursorInfo.SortOrders,
+	}
+	for _, cursorValue := range cursorInfo.Values {
+		fieldValue, err := decodeCursorValue(cursorValue)
+		if err != nil {
+			return types.CursorType{}, err
+		}
+		result.Values = append(result.Values, fieldValue)
+	}
+	return result, nil
+}
+
+// ComputeKeysetSortItems function returns the sortItems, with the id field (ascending) as the last/tie-breaker
+// sort-field, if not specified. Null-ordering (nulls first/last) is not supported by keyset/cursor paging
+func ComputeKeysetSortItems(sortItems types.SortItemsType) (types.SortItemsType, error) {
+	var keysetItems types.SortItemsType
+	hasId := false
+	for _, item := range sortItems {
+		if item.Nulls != "" {
+			return nil, errors.New(fmt.Sprintf("sort nulls for field-name [%v] is not supported by cursor-paging", item.FieldName))
+		}
+		if item.FieldName == "id" {
+			hasId = true
+		}
+		keysetItems = append(keysetItems, item)
+	}
+	if !hasId {
+		keysetItems = append(keysetItems, types.SortItemType{FieldName: "id", Order: 1})
+	}
+	return keysetItems, nil
+}
+
+// ReverseSortItems function reverses the sort-order of the sortItems, to read the previous-page records
+func ReverseSortItems(sortItems types.SortItemsType) types.SortItemsType {
+	var reverseItems types.SortItemsType
+	for _, item := range sortItems {
+		reverseItems = append(reverseItems, types.SortItemType{
+			FieldName: item.FieldName,
+			Order:     -item.Order,
+		})
+	}
+	return reverseItems
+}
+
+// ComputeKeysetQuery function computes the keyset-condition (without the WHERE keyword), to read the records
+// after the cursor values, in the sortItems order, i.e. (a > $1) OR (a = $1 AND b < $2) OR (a = $1 AND b = $2 AND id > $3).
+// The value-placeholders start from fieldLength + 1
+func ComputeKeysetQuery(sortItems types.SortItemsType, cursorValues []interface{}, fieldLength int) (string, []interface{}, error) {
+	if len(sortItems) < 1 || len(sortItems) != len(cursorValues) {
+		return "", nil, errors.New("sort-items and cursor-values are required and must be of the same length")
+	}
+	var orItems []string
+	for i, item := range sortItems {
+		var andItems []string
+		for j := 0; j < i; j++ {
+			andItems = append(andItems, fmt.Sprintf("%v = $%v", sortItems[j].FieldName, fieldLength+j+1))
+		}
+		switch item.Order {
+		case 1:
+			andItems = append(andItems, fmt.Sprintf("%v > $%v", item.FieldName, fieldLength+i+1))
+		case -1:
+			andItems = append(andItems, fmt.Sprintf("%v < $%v", item.FieldName, fieldLength+i+1))
+		default:
+			return "", nil, errors.New(fmt.Sprintf("sort order for field-name [%v] must be 1 (asc) or -1 (desc)", item.FieldName))
+		}
+		orItems = append(orItems, "("+strings.Join(andItems, " AND ")+")")
+	}
+	return "(" + strings.Join(orItems, " OR ") + ")", cursorValues, nil
+}
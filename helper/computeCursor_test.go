@@ -0,0 +1,157 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: keyset/cursor-paging scripts and cursor encode/decode test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	logAt := time.Date(2026, 10, 18, 8, 30, 15, 123456789, time.UTC)
+	cursorCases := []struct {
+		name    string
+		cursor  types.CursorType
+		values  []interface{}
+		isError bool
+	}{
+		{
+			name: "should encode and decode the cursor time, string, int, float and bool values:",
+			cursor: types.CursorType{
+				Direction:  types.CursorNext,
+				FieldNames: []string{"log_at", "name", "age", "score", "active", "id"},
+				SortOrders: []int{-1, 1, 1, -1, 1, 1},
+				Values:     []interface{}{logAt, "abi", 30, float32(4.5), true, "id-1"},
+			},
+			values: []interface{}{logAt, "abi", int64(30), 4.5, true, "id-1"},
+		},
+		{
+			name: "should encode and decode the cursor uuid value as string:",
+			cursor: types.CursorType{
+				Direction:  types.CursorPrev,
+				FieldNames: []string{"id"},
+				SortOrders: []int{1},
+				Values:     []interface{}{[16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}},
+			},
+			values: []interface{}{"12345678-9abc-def0-1234-56789abcdef0"},
+		},
+		{
+			name: "should return an error for the null cursor value:",
+			cursor: types.CursorType{
+				Direction:  types.CursorNext,
+				FieldNames: []string{"name"},
+				SortOrders: []int{1},
+				Values:     []interface{}{nil},
+			},
+			isError: true,
+		},
+		{
+			name: "should return an error for the invalid cursor direction:",
+			cursor: types.CursorType{
+				Direction:  "last",
+				FieldNames: []string{"id"},
+				SortOrders: []int{1},
+				Values:     []interface{}{"id-1"},
+			},
+			isError: true,
+		},
+	}
+	for _, tc := range cursorCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				cursor, err := EncodeCursor(tc.cursor)
+				mctest.AssertEquals(t, err != nil, tc.isError, "encode-cursor error should be as expected")
+				if err != nil {
+					return
+				}
+				res, err := DecodeCursor(cursor)
+				mctest.AssertEquals(t, err, nil, "decode-cursor error should be nil")
+				mctest.AssertEquals(t, res.Direction, tc.cursor.Direction, "decoded cursor direction should be as encoded")
+				mctest.AssertStrictEquals(t, res.FieldNames, tc.cursor.FieldNames, "decoded cursor field-names should be as encoded")
+				mctest.AssertStrictEquals(t, res.SortOrders, tc.cursor.SortOrders, "decoded cursor sort-orders should be as encoded")
+				mctest.AssertEquals(t, len(res.Values), len(tc.values), "decoded cursor values count should be as encoded")
+				for i, value := range tc.values {
+					if timeValue, ok := value.(time.Time); ok {
+						resValue, _ := res.Values[i].(time.Time)
+						mctest.AssertEquals(t, resValue.Equal(timeValue), true, "decoded cursor time value should be as encoded")
+						continue
+					}
+					mctest.AssertEquals(t, res.Values[i], value, "decoded cursor value should be as encoded")
+				}
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return an error for the invalid/tampered cursor:",
+		TestFunc: func() {
+			for _, cursor := range []string{"not-a-cursor", "eyJkIjoibmV4dCJ9", ""} {
+				_, err := DecodeCursor(cursor)
+				mctest.AssertNotEquals(t, err, nil, "decode-cursor error should not be nil")
+			}
+		},
+	})
+
+	keysetCases := []struct {
+		name        string
+		sortItems   types.SortItemsType
+		values      []interface{}
+		fieldLength int
+		keysetQuery string
+		isError     bool
+	}{
+		{
+			name:        "should compute the keyset condition for the id:",
+			sortItems:   types.SortItemsType{{FieldName: "id", Order: 1}},
+			values:      []interface{}{"id-1"},
+			keysetQuery: "((id > $1))",
+		},
+		{
+			name:        "should compute the keyset condition for the sort-fields and id, after the where placeholders:",
+			sortItems:   types.SortItemsType{{FieldName: "log_at", Order: -1}, {FieldName: "id", Order: 1}},
+			values:      []interface{}{logAt, "id-1"},
+			fieldLength: 2,
+			keysetQuery: "((log_at < $3) OR (log_at = $3 AND id > $4))",
+		},
+		{
+			name:      "should return an error for the sort-items and values count mismatch:",
+			sortItems: types.SortItemsType{{FieldName: "log_at", Order: -1}, {FieldName: "id", Order: 1}},
+			values:    []interface{}{logAt},
+			isError:   true,
+		},
+		{
+			name:      "should return an error for the invalid sort order:",
+			sortItems: types.SortItemsType{{FieldName: "id", Order: 0}},
+			values:    []interface{}{"id-1"},
+			isError:   true,
+		},
+	}
+	for _, tc := range keysetCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				keysetQuery, _, err := ComputeKeysetQuery(tc.sortItems, tc.values, tc.fieldLength)
+				mctest.AssertEquals(t, err != nil, tc.isError, "keyset-query error should be as expected")
+				mctest.AssertEquals(t, keysetQuery, tc.keysetQuery, "keyset-query should be as expected")
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should add the id tie-breaker and reverse the keyset sort-items:",
+		TestFunc: func() {
+			keysetItems, err := ComputeKeysetSortItems(types.SortItemsType{{FieldName: "log_at", Order: -1}})
+			mctest.AssertEquals(t, err, nil, "keyset sort-items error should be nil")
+			mctest.AssertStrictEquals(t, keysetItems, types.SortItemsType{{FieldName: "log_at", Order: -1}, {FieldName: "id", Order: 1}}, "keyset sort-items should end with the id")
+			mctest.AssertStrictEquals(t, ReverseSortItems(keysetItems), types.SortItemsType{{FieldName: "log_at", Order: 1}, {FieldName: "id", Order: -1}}, "reversed keyset sort-items should be as expected")
+			_, err = ComputeKeysetSortItems(types.SortItemsType{{FieldName: "log_at", Order: -1, Nulls: "last"}})
+			mctest.AssertNotEquals(t, err, nil, "keyset sort-items nulls error should not be nil")
+		},
+	})
+	mctest.PostTestResult()
+}
@@ -8,36 +8,64 @@ import (
 	"errors"
 	"fmt"
 	"github.com/abbeymart/mccrud/types"
+	"strings"
 )
 
 // ComputeDeleteQueryById function computes delete SQL script by id(s)
-func ComputeDeleteQueryById(tableName string, recordIds []string) (string, error) {
+// DeleteQuery is the complete delete script, with the where-script (WhereQuery) and value-placeholders for FieldValues
+func ComputeDeleteQueryById(tableName string, recordIds []string) (types.DeleteQueryResponseType, error) {
 	if tableName == "" || len(recordIds) < 1 {
-		return "", errors.New("table/collection name and doc-Ids are required for the delete-by-id operation")
+		return types.DeleteQueryResponseType{}, errors.New("table/collection name and doc-Ids are required for the delete-by-id operation")
 	}
-	// validated recordIds, strictly contains string/UUID values, to avoid SQL-injection
+	// recordIds are passed as placeholder values, to avoid SQL-injection
 	// from / where condition (where-in-values)
-	whereIds := ""
-	idLen := len(recordIds)
-	for idCount, id := range recordIds {
-		whereIds += "'" + id + "'"
-		if idLen > 1 && idCount < idLen-1 {
-			whereIds += ", "
-		}
+	var fieldValues []interface{}
+	for _, id := range recordIds {
+		fieldValues = append(fieldValues, id)
 	}
-	deleteQuery := "DELETE FROM " + tableName + " WHERE id IN(" + whereIds + ")"
-	return deleteQuery, nil
+	whereQuery := fmt.Sprintf("WHERE id IN(%v)", ComputePlaceholders(1, len(recordIds)))
+	return types.DeleteQueryResponseType{
+		DeleteQuery: "DELETE FROM " + tableName + " " + whereQuery,
+		WhereQuery:  whereQuery,
+		FieldValues: fieldValues,
+	}, nil
 }
 
 // ComputeDeleteQueryByParam function computes delete SQL script by parameter specifications
-func ComputeDeleteQueryByParam(tableName string, where types.QueryParamType) (string, error) {
+// DeleteQuery is the complete delete script, with the where-script (WhereQuery) and value-placeholders for FieldValues
+func ComputeDeleteQueryByParam(tableName string, where types.QueryParamType) (types.DeleteQueryResponseType, error) {
 	if tableName == "" || len(where) < 1 {
-		return "", errors.New("table/collection name and where/query-condition are required for the delete-by-param operation")
+		return types.DeleteQueryResponseType{}, errors.New("table/collection name and where/query-condition are required for the delete-by-param operation")
 	}
-	if whereParam, err := ComputeWhereQuery(where); err == nil {
-		deleteScript := fmt.Sprintf("DELETE FROM %v %v", tableName, whereParam)
-		return deleteScript, nil
+	if whereRes, err := ComputeWhereQuery(where, 0); err == nil {
+		return types.DeleteQueryResponseType{
+			DeleteQuery: fmt.Sprintf("DELETE FROM %v %v", tableName, whereRes.WhereQuery),
+			WhereQuery:  whereRes.WhereQuery,
+			FieldValues: whereRes.FieldValues,
+		}, nil
 	} else {
-		return "", errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
+		return types.DeleteQueryResponseType{}, errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
+	}
+}
+
+// ComputeBackupQuery function computes the backup-table (same columns as the table, if not exists) create script
+// and the archive (copy all records) script, for the delete-all operation. The archive script names the table
+// columns (tableFields, e.g. TableColumnNames) explicitly, i.e. the columns are copied by name, not by position,
+// to an existing backup-table (e.g. created before a table column change).
+func ComputeBackupQuery(tableName string, backupTable string, tableFields []string) (string, string, error) {
+	if !IsValidFieldName(tableName) || !IsValidFieldName(backupTable) || tableName == backupTable {
+		return "", "", errors.New(fmt.Sprintf("valid and distinct table-name [%v] and backup-table-name [%v] are required for the backup operation", tableName, backupTable))
+	}
+	if len(tableFields) < 1 {
+		return "", "", errors.New("table-fields are required for the backup operation")
+	}
+	for _, fieldName := range tableFields {
+		if !IsValidFieldName(fieldName) {
+			return "", "", errors.New(fmt.Sprintf("invalid field_name[%v]", fieldName))
+		}
 	}
+	fields := strings.Join(tableFields, ", ")
+	createQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (LIKE %v)", backupTable, tableName)
+	archiveQuery := fmt.Sprintf("INSERT INTO %v(%v) SELECT %v FROM %v", backupTable, fields, fields, tableName)
+	return createQuery, archiveQuery, nil
 }
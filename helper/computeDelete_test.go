@@ -0,0 +1,67 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute delete-SQL scripts test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestComputeDeleteQuery(t *testing.T) {
+	testCases := []struct {
+		name        string
+		recordIds   []string
+		where       types.QueryParamType
+		deleteQuery string
+		fieldValues []interface{}
+	}{
+		{
+			name:        "should compute the delete-by-id query, with the id placeholders:",
+			recordIds:   []string{"id-1"},
+			deleteQuery: "DELETE FROM users WHERE id IN($1)",
+			fieldValues: []interface{}{"id-1"},
+		},
+		{
+			name: "should compute the delete-by-param query, with the where placeholders:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"neq": "' OR 1=1 --"}}},
+				}},
+			},
+			deleteQuery: "DELETE FROM users WHERE (name<>$1)",
+			fieldValues: []interface{}{"' OR 1=1 --"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				var res types.DeleteQueryResponseType
+				var err error
+				if len(tc.recordIds) > 0 {
+					res, err = ComputeDeleteQueryById("users", tc.recordIds)
+				} else {
+					res, err = ComputeDeleteQueryByParam("users", tc.where)
+				}
+				mctest.AssertEquals(t, err, nil, "delete-query error should be nil")
+				mctest.AssertEquals(t, res.DeleteQuery, tc.deleteQuery, "delete-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "delete-query values should be as expected")
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the soft-delete-all query, for the not-deleted records:",
+		TestFunc: func() {
+			res, err := ComputeSoftDeleteAllQuery("users", "user-1")
+			mctest.AssertEquals(t, err, nil, "soft-delete-all-query error should be nil")
+			mctest.AssertEquals(t, res.DeleteQuery, "UPDATE users SET deleted_at=CURRENT_TIMESTAMP, deleted_by=$1 WHERE deleted_at IS NULL", "soft-delete-all-query should be as expected")
+			mctest.AssertEquals(t, res.WhereQuery, "WHERE deleted_at IS NULL", "soft-delete-all where-query should be as expected")
+			mctest.AssertStrictEquals(t, res.FieldValues, []interface{}{"user-1"}, "soft-delete-all-query values should be as expected")
+		},
+	})
+	mctest.PostTestResult()
+}
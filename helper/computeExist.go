@@ -0,0 +1,136 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute record-exist (duplicate-check) SQL script
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"sort"
+	"strings"
+)
+
+// ComputeExistQuery function computes the record-exist (duplicate-check) SQL script, with value-placeholders, for the
+// existParam field-names and the record. The field-value is the record field-value or, if not in the record, the
+// existParam field-value; the check is not required (empty script), if any of the field-values is not specified (nil).
+// The excludeIds records (e.g. the record(s) to update) and the record matching the conflictFields values of the
+// record (upsert on-conflict target, i.e. the record to update), if specified, are excluded from the check.
+func ComputeExistQuery(tableName string, existParam types.ExistParamType, rec types.ActionParamType, excludeIds []string, conflictFields []string) (string, []string, []interface{}, error) {
+	if tableName == "" || len(existParam) < 1 {
+		return "", nil, nil, errors.New("table-name and exist-param are required for the record-exist check")
+	}
+	var fieldNames []string
+	for fieldName := range existParam {
+		if !IsValidFieldName(fieldName) {
+			return "", nil, nil, errors.New(fmt.Sprintf("invalid exist-param field_name[%v]", fieldName))
+		}
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	var whereItems []string
+	var fieldValues []interface{}
+	for _, fieldName := range fieldNames {
+		fieldValue, ok := rec[fieldName]
+		if !ok {
+			fieldValue = existParam[fieldName]
+		}
+		if fieldValue == nil {
+			return "", fieldNames, nil, nil
+		}
+		currentFieldValue, err := ComputeFieldValue(fieldName, fieldValue)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		fieldValues = append(fieldValues, currentFieldValue)
+		whereItems = append(whereItems, fmt.Sprintf("%v = $%v", fieldName, len(fieldValues)))
+	}
+	if len(excludeIds) > 0 {
+		whereItems = append(whereItems, fmt.Sprintf("id NOT IN (%v)", ComputePlaceholders(len(fieldValues)+1, len(excludeIds))))
+		for _, id := range excludeIds {
+			fieldValues = append(fieldValues, id)
+		}
+	}
+	// the on-conflict target record, if the record includes all the conflict field-values
+	var conflictItems []string
+	var conflictValues []interface{}
+	for _, fieldName := range conflictFields {
+		fieldValue, ok := rec[fieldName]
+		if !ok || fieldValue == nil || !IsValidFieldName(fieldName) {
+			conflictItems = nil
+			break
+		}
+		currentFieldValue, err := ComputeFieldValue(fieldName, fieldValue)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		conflictValues = append(conflictValues, currentFieldValue)
+		conflictItems = append(conflictItems, fmt.Sprintf("%v = $%v", fieldName, len(fieldValues)+len(conflictValues)))
+	}
+	if len(conflictItems) > 0 {
+		whereItems = append(whereItems, fmt.Sprintf("NOT (%v)", strings.Join(conflictItems, " AND ")))
+		fieldValues = append(fieldValues, conflictValues...)
+	}
+	existQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %v WHERE %v)", tableName, strings.Join(whereItems, " AND "))
+	return existQuery, fieldNames, fieldValues, nil
+}
+
+// ComputeTempTableExistQuery function computes the record-exist (duplicate-check) SQL script, for the records loaded
+// into the temp-table (copy/staged create): the check is true, if any of the temp-table records collides with an
+// existing record, except the record matching its conflictFields values (on-conflict target), if specified, or with
+// another temp-table record. The field-value is the temp-table field (tableFields) or, if not loaded, the existParam
+// field-value; the check is not required (empty script), if any of the field-values is not specified (nil).
+func ComputeTempTableExistQuery(tableName string, tempTableName string, existParam types.ExistParamType, tableFields []string, conflictFields []string) (string, []string, []interface{}, error) {
+	if tableName == "" || tempTableName == "" || len(existParam) < 1 {
+		return "", nil, nil, errors.New("table-name, temp-table-name and exist-param are required for the record-exist check")
+	}
+	var fieldNames []string
+	for fieldName := range existParam {
+		if !IsValidFieldName(fieldName) {
+			return "", nil, nil, errors.New(fmt.Sprintf("invalid exist-param field_name[%v]", fieldName))
+		}
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	var matchItems, groupFields, notNullItems []string
+	var fieldValues []interface{}
+	for _, fieldName := range fieldNames {
+		if ArrayStringContains(tableFields, fieldName) {
+			matchItems = append(matchItems, fmt.Sprintf("x.%v = t.%v", fieldName, fieldName))
+			groupFields = append(groupFields, "t."+fieldName)
+			notNullItems = append(notNullItems, fmt.Sprintf("t.%v IS NOT NULL", fieldName))
+			continue
+		}
+		fieldValue := existParam[fieldName]
+		if fieldValue == nil {
+			return "", fieldNames, nil, nil
+		}
+		currentFieldValue, err := ComputeFieldValue(fieldName, fieldValue)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		fieldValues = append(fieldValues, currentFieldValue)
+		matchItems = append(matchItems, fmt.Sprintf("x.%v = $%v", fieldName, len(fieldValues)))
+	}
+	// the on-conflict target record, if all the conflict-fields are loaded
+	var conflictItems []string
+	for _, fieldName := range conflictFields {
+		if !ArrayStringContains(tableFields, fieldName) || !IsValidFieldName(fieldName) {
+			conflictItems = nil
+			break
+		}
+		conflictItems = append(conflictItems, fmt.Sprintf("x.%v = t.%v", fieldName, fieldName))
+	}
+	if len(conflictItems) > 0 {
+		matchItems = append(matchItems, fmt.Sprintf("NOT (%v)", strings.Join(conflictItems, " AND ")))
+	}
+	tableExistQuery := fmt.Sprintf("EXISTS (SELECT 1 FROM %v t JOIN %v x ON %v)", tempTableName, tableName, strings.Join(matchItems, " AND "))
+	// duplicates within the temp-table records
+	duplicateQuery := fmt.Sprintf("(SELECT COUNT(*) FROM %v) > 1", tempTableName)
+	if len(groupFields) > 0 {
+		duplicateQuery = fmt.Sprintf("EXISTS (SELECT 1 FROM %v t WHERE %v GROUP BY %v HAVING COUNT(*) > 1)", tempTableName,
+			strings.Join(notNullItems, " AND "), strings.Join(groupFields, ", "))
+	}
+	return fmt.Sprintf("SELECT %v OR %v", tableExistQuery, duplicateQuery), fieldNames, fieldValues, nil
+}
@@ -0,0 +1,50 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute child-table (foreign-key) references and sub-items SQL scripts, for the delete operations
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"strings"
+)
+
+// ComputeReferencesQuery function computes the (pg_constraint) query for the single-column foreign-key references
+// to the parent-table, by the childTables (all referencing tables, if not specified).
+// The query returns the child-table, child-field and parent-field names, in the order of the child-table names.
+func ComputeReferencesQuery(parentTable string, childTables []string) (string, []interface{}, error) {
+	if !IsValidFieldName(parentTable) {
+		return "", nil, errors.New(fmt.Sprintf("invalid parent-table name [%v]", parentTable))
+	}
+	referencesQuery := "SELECT cl.relname, ca.attname, pa.attname FROM pg_constraint c " +
+		"JOIN pg_class cl ON cl.oid = c.conrelid JOIN pg_class pl ON pl.oid = c.confrelid " +
+		"JOIN pg_attribute ca ON ca.attrelid = c.conrelid AND ca.attnum = c.conkey[1] " +
+		"JOIN pg_attribute pa ON pa.attrelid = c.confrelid AND pa.attnum = c.confkey[1] " +
+		"WHERE c.contype = 'f' AND array_length(c.conkey, 1) = 1 AND pl.relname = $1 AND pg_table_is_visible(pl.oid)"
+	fieldValues := []interface{}{parentTable}
+	if len(childTables) > 0 {
+		for _, childTable := range childTables {
+			if !IsValidFieldName(childTable) {
+				return "", nil, errors.New(fmt.Sprintf("invalid child-table name [%v]", childTable))
+			}
+		}
+		referencesQuery += " AND cl.relname = ANY($2)"
+		fieldValues = append(fieldValues, childTables)
+	}
+	return referencesQuery + " ORDER BY cl.relname, ca.attname", fieldValues, nil
+}
+
+// ComputeSubItemsWhereQuery function computes the where-script for the child-table records (sub-items) that reference
+// the parent-table records met by the parentWhereQuery (where-script), i.e. with the same value-placeholders
+func ComputeSubItemsWhereQuery(reference types.TableReferenceType, parentWhereQuery string) string {
+	return fmt.Sprintf("WHERE %v IN (%v)", reference.FieldName, strings.TrimSpace(fmt.Sprintf("SELECT %v FROM %v %v",
+		reference.ParentField, reference.ParentTable, parentWhereQuery)))
+}
+
+// ComputeSubItemsExistsQuery function computes the exists script for the child-table records (sub-items) that
+// reference the parent-table records met by the parentWhereQuery (where-script)
+func ComputeSubItemsExistsQuery(reference types.TableReferenceType, parentWhereQuery string) string {
+	return fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %v %v)", reference.TableName, ComputeSubItemsWhereQuery(reference, parentWhereQuery))
+}
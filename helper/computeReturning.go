@@ -0,0 +1,33 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute RETURNING script, for the insert, update and delete statements
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"sort"
+	"strings"
+)
+
+// ComputeReturningQuery function computes the RETURNING script, for the projected (true) fields of the projectParams,
+// including the id field, or all fields (*), if no field is projected
+func ComputeReturningQuery(projectParams types.ProjectParamType) (string, error) {
+	var fieldNames []string
+	for fieldName, include := range projectParams {
+		if !include || fieldName == "id" {
+			continue
+		}
+		if !IsValidFieldName(fieldName) {
+			return "", errors.New(fmt.Sprintf("invalid project-param field_name[%v]", fieldName))
+		}
+		fieldNames = append(fieldNames, fieldName)
+	}
+	if len(fieldNames) < 1 {
+		return " RETURNING *", nil
+	}
+	sort.Strings(fieldNames)
+	return " RETURNING id, " + strings.Join(fieldNames, ", "), nil
+}
@@ -0,0 +1,172 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute full-text search (tsvector) conditions, ranking and GIN index SQL scripts
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctypes"
+	"sort"
+	"strings"
+)
+
+// default text-search config
+const defaultSearchConfig = "english"
+
+// searchParamType is the full-text search field-names, text-search config and web-search query
+type searchParamType struct {
+	fieldNames []string
+	config     string
+	query      string
+}
+
+// computeSearchParam function returns the search-param from the search field-name (comma-separated field-names)
+// and value (query string, or query and config map)
+func computeSearchParam(fieldName string, fieldValue interface{}) (searchParamType, error) {
+	searchParam := searchParamType{config: defaultSearchConfig}
+	for _, name := range strings.Split(fieldName, ",") {
+		searchParam.fieldNames = append(searchParam.fieldNames, strings.TrimSpace(name))
+	}
+	switch fVal := fieldValue.(type) {
+	case string:
+		searchParam.query = fVal
+	case map[string]interface{}:
+		searchParam.query, _ = fVal["query"].(string)
+		if config, ok := fVal["config"].(string); ok && config != "" {
+			searchParam.config = config
+		}
+	case map[string]string:
+		searchParam.query = fVal["query"]
+		if fVal["config"] != "" {
+			searchParam.config = fVal["config"]
+		}
+	}
+	if searchParam.query == "" {
+		return searchParamType{}, errors.New(fmt.Sprintf("search query (string) is required for the search field-name(s) [%v]", fieldName))
+	}
+	return searchParam, nil
+}
+
+// ComputeSearchVector function computes the tsvector script of the field-names, for the text-search config,
+// i.e. the same script for the search conditions and the (expression) GIN index
+func ComputeSearchVector(config string, fieldNames []string) (string, error) {
+	if !IsValidFieldName(config) {
+		return "", errors.New(fmt.Sprintf("invalid text-search config [%v]", config))
+	}
+	if len(fieldNames) < 1 {
+		return "", errors.New("search field-name(s) required")
+	}
+	for _, fieldName := range fieldNames {
+		if !IsValidFieldName(fieldName) {
+			return "", errors.New(fmt.Sprintf("invalid search field-name [%v]", fieldName))
+		}
+	}
+	if len(fieldNames) == 1 {
+		return fmt.Sprintf("to_tsvector('%v', %v)", config, fieldNames[0]), nil
+	}
+	var fieldScripts []string
+	for _, fieldName := range fieldNames {
+		fieldScripts = append(fieldScripts, fmt.Sprintf("coalesce(%v, '')", fieldName))
+	}
+	return fmt.Sprintf("to_tsvector('%v', %v)", config, strings.Join(fieldScripts, " || ' ' || ")), nil
+}
+
+// computeSearchItem function computes the search condition script, with the query value-placeholder
+func computeSearchItem(fieldName string, fieldValue interface{}, placeholderIndex int) (string, []interface{}, error) {
+	searchParam, err := computeSearchParam(fieldName, fieldValue)
+	if err != nil {
+		return "", nil, err
+	}
+	vectorScript, err := ComputeSearchVector(searchParam.config, searchParam.fieldNames)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%v @@ websearch_to_tsquery('%v', $%v)", vectorScript, searchParam.config, placeholderIndex), []interface{}{searchParam.query}, nil
+}
+
+// computeSearchParams function returns the search-params of the where-groups (including nested groups), in order
+func computeSearchParams(where types.QueryParamType) ([]searchParamType, error) {
+	var searchParams []searchParamType
+	for _, group := range where {
+		for _, gItem := range group.GroupItems {
+			if gItem.Group != nil {
+				nestedParams, err := computeSearchParams(types.QueryParamType{*gItem.Group})
+				if err != nil {
+					return nil, err
+				}
+				searchParams = append(searchParams, nestedParams...)
+			}
+			for fieldName, opVal := range gItem.GroupItem {
+				for fieldOperator, fieldValue := range opVal {
+					if strings.ToLower(fieldOperator) != types.Search || gItem.GroupItemNot {
+						continue
+					}
+					searchParam, err := computeSearchParam(fieldName, fieldValue)
+					if err != nil {
+						return nil, err
+					}
+					searchParams = append(searchParams, searchParam)
+				}
+			}
+		}
+	}
+	return searchParams, nil
+}
+
+// ComputeSearchRankQuery function computes the ts_rank script (the sum of the ranks, for multiple search conditions)
+// for the search where-conditions, with the query value-placeholders starting from $placeholderIndex.
+// The empty script is returned, if the where-params contains no search conditions.
+func ComputeSearchRankQuery(where types.QueryParamType, placeholderIndex int) (string, []interface{}, error) {
+	searchParams, err := computeSearchParams(where)
+	if err != nil {
+		return "", nil, err
+	}
+	var rankScripts []string
+	var fieldValues []interface{}
+	for _, searchParam := range searchParams {
+		vectorScript, err := ComputeSearchVector(searchParam.config, searchParam.fieldNames)
+		if err != nil {
+			return "", nil, err
+		}
+		rankScripts = append(rankScripts, fmt.Sprintf("ts_rank(%v, websearch_to_tsquery('%v', $%v))", vectorScript,
+			searchParam.config, placeholderIndex+len(fieldValues)))
+		fieldValues = append(fieldValues, searchParam.query)
+	}
+	return strings.Join(rankScripts, " + "), fieldValues, nil
+}
+
+// ComputeSearchIndexQuery function computes the GIN (expression) index script, for the search conditions on the
+// model field-names (all string/text fields, sorted by field-name, if not specified) and the text-search config
+// (default: english). The search field-names (and order) and config must match the index, to use the index.
+// The index-name, <table>_<field-names>_search_idx, is capped at 63 bytes, with a hash suffix (computeIdentifierName).
+func ComputeSearchIndexQuery(model mctypes.ModelType, fieldNames []string, config string) (string, error) {
+	if !IsValidFieldName(model.TableName) {
+		return "", errors.New(fmt.Sprintf("invalid table-name [%v]", model.TableName))
+	}
+	if config == "" {
+		config = defaultSearchConfig
+	}
+	if len(fieldNames) < 1 {
+		for fieldName, fieldDesc := range model.RecordDesc {
+			if computeFieldKind(fieldDesc.FieldType) == fieldKindString {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		}
+		sort.Strings(fieldNames)
+	}
+	for _, fieldName := range fieldNames {
+		fieldDesc, ok := model.RecordDesc[fieldName]
+		if !ok || computeFieldKind(fieldDesc.FieldType) != fieldKindString {
+			return "", errors.New(fmt.Sprintf("search field-name [%v] must be a string/text field of the model", fieldName))
+		}
+	}
+	vectorScript, err := ComputeSearchVector(config, fieldNames)
+	if err != nil {
+		return "", err
+	}
+	indexName := computeIdentifierName(fmt.Sprintf("%v_%v_search_idx", model.TableName, strings.Join(fieldNames, "_")))
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %v ON %v USING GIN (%v)", indexName, model.TableName, vectorScript), nil
+}
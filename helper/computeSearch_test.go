@@ -0,0 +1,171 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: full-text search conditions, ranking and GIN index scripts test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/datatypes"
+	"testing"
+)
+
+func TestComputeSearch(t *testing.T) {
+	searchCases := []struct {
+		name        string
+		where       types.QueryParamType
+		whereQuery  string
+		fieldValues []interface{}
+		isError     bool
+	}{
+		{
+			name:        "should compute the search condition, for the default config:",
+			where:       whereItemParams("title", types.Search, "go sql"),
+			whereQuery:  "WHERE (to_tsvector('english', title) @@ websearch_to_tsquery('english', $1))",
+			fieldValues: []interface{}{"go sql"},
+		},
+		{
+			name:  "should compute the search condition, for the multiple field-names and the config:",
+			where: whereItemParams("title, body", types.Search, map[string]interface{}{"query": "go -java", "config": "simple"}),
+			whereQuery: "WHERE (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(body, '')) @@ " +
+				"websearch_to_tsquery('simple', $1))",
+			fieldValues: []interface{}{"go -java"},
+		},
+		{
+			name:    "should return an error for the invalid text-search config:",
+			where:   whereItemParams("title", types.Search, map[string]string{"query": "go", "config": "english'); DROP TABLE users; --"}),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the invalid search field-name:",
+			where:   whereItemParams("title, body; DROP TABLE users", types.Search, "go"),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the missing search query:",
+			where:   whereItemParams("title", types.Search, map[string]interface{}{"config": "simple"}),
+			isError: true,
+		},
+	}
+	for _, tc := range searchCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := ComputeWhereQuery(tc.where, 0)
+				mctest.AssertEquals(t, err != nil, tc.isError, "search where-query error should be as expected")
+				mctest.AssertEquals(t, res.WhereQuery, tc.whereQuery, "search where-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "search where-query values should be as expected")
+			},
+		})
+	}
+
+	// name = $1 AND title search $2 AND (body search $3 OR NOT body search $4)
+	where := types.QueryParamType{
+		{GroupItems: []types.QueryItemType{
+			{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}}},
+			{GroupItemOrder: 1, GroupItem: map[string]map[string]interface{}{"title": {types.Search: "go"}}},
+			{GroupItemOrder: 2, Group: &types.QueryGroupType{GroupItems: []types.QueryItemType{
+				{GroupItem: map[string]map[string]interface{}{"body": {types.Search: "sql"}}, GroupItemOp: "or"},
+				{GroupItemOrder: 1, GroupItemNot: true, GroupItem: map[string]map[string]interface{}{"body": {types.Search: "java"}}},
+			}}},
+		}},
+	}
+	selectCases := []struct {
+		name        string
+		where       types.QueryParamType
+		tableFields []string
+		selectQuery string
+		fieldValues []interface{}
+		isError     bool
+	}{
+		{
+			name:        "should compute the search-rank field, for the (not negated) search conditions, after the where placeholders:",
+			where:       where,
+			tableFields: []string{"id", "title", types.SearchRankField},
+			selectQuery: "SELECT id, title, ts_rank(to_tsvector('english', title), websearch_to_tsquery('english', $5)) + " +
+				"ts_rank(to_tsvector('english', body), websearch_to_tsquery('english', $6)) AS search_rank FROM posts " +
+				"WHERE (name=$1 AND to_tsvector('english', title) @@ websearch_to_tsquery('english', $2) AND " +
+				"(to_tsvector('english', body) @@ websearch_to_tsquery('english', $3) OR " +
+				"NOT (to_tsvector('english', body) @@ websearch_to_tsquery('english', $4))))",
+			fieldValues: []interface{}{"abi", "go", "sql", "java", "go", "sql"},
+		},
+		{
+			name:        "should return an error for the search-rank field, without a search condition:",
+			where:       whereItemParams("name", "eq", "abi"),
+			tableFields: []string{"id", types.SearchRankField},
+			isError:     true,
+		},
+	}
+	for _, tc := range selectCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := ComputeSelectQueryByParam("posts", tc.where, tc.tableFields)
+				mctest.AssertEquals(t, err != nil, tc.isError, "search select-query error should be as expected")
+				mctest.AssertEquals(t, res.SelectQuery, tc.selectQuery, "search select-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "search select-query values should be as expected")
+			},
+		})
+	}
+
+	model := mctypes.ModelType{
+		TableName: "posts",
+		RecordDesc: mctypes.RecordDescType{
+			"title": {FieldType: datatypes.String},
+			"body":  {FieldType: datatypes.Text},
+			"views": {FieldType: datatypes.Integer},
+		},
+	}
+	indexCases := []struct {
+		name       string
+		tableName  string
+		fieldNames []string
+		config     string
+		indexQuery string
+		isError    bool
+	}{
+		{
+			name: "should compute the search index, for all the model string/text fields:",
+			indexQuery: "CREATE INDEX IF NOT EXISTS posts_body_title_search_idx ON posts USING GIN " +
+				"(to_tsvector('english', coalesce(body, '') || ' ' || coalesce(title, '')))",
+		},
+		{
+			name:       "should compute the search index, for the field-names and config:",
+			fieldNames: []string{"title"},
+			config:     "simple",
+			indexQuery: "CREATE INDEX IF NOT EXISTS posts_title_search_idx ON posts USING GIN (to_tsvector('simple', title))",
+		},
+		{
+			name:       "should cap the search index-name at 63 bytes, with the hash suffix:",
+			tableName:  "posts_of_the_editorial_and_marketing_teams_archive",
+			fieldNames: []string{"title"},
+			indexQuery: "CREATE INDEX IF NOT EXISTS posts_of_the_editorial_and_marketing_teams_archive_tit_4dda98a8 " +
+				"ON posts_of_the_editorial_and_marketing_teams_archive USING GIN (to_tsvector('english', title))",
+		},
+		{
+			name:       "should return an error for the non-text search field:",
+			fieldNames: []string{"views"},
+			isError:    true,
+		},
+	}
+	for _, tc := range indexCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				indexModel := model
+				if tc.tableName != "" {
+					indexModel.TableName = tc.tableName
+				}
+				indexQuery, err := ComputeSearchIndexQuery(indexModel, tc.fieldNames, tc.config)
+				mctest.AssertEquals(t, err != nil, tc.isError, "search index-query error should be as expected")
+				mctest.AssertEquals(t, indexQuery, tc.indexQuery, "search index-query should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
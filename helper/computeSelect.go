@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctypes"
+	"sort"
 	"strings"
 )
 
@@ -22,39 +24,95 @@ func ComputeSelectQueryAll(tableName string, tableFields []string) (string, erro
 }
 
 // ComputeSelectQueryById compose select SQL script by id(s)
-func ComputeSelectQueryById(tableName string, recordIds []string, tableFields []string) (string, error) {
+// SelectQuery is the complete select script, with the where-script (WhereQuery) and value-placeholders for FieldValues
+func ComputeSelectQueryById(tableName string, recordIds []string, tableFields []string) (types.SelectQueryResponseType, error) {
 	if tableName == "" || len(recordIds) < 1 || len(tableFields) < 1 {
-		return "", errors.New("table-name, table-fields and record-ids are required to perform the select operation")
+		return types.SelectQueryResponseType{}, errors.New("table-name, table-fields and record-ids are required to perform the select operation")
 	}
 	// get record(s) based on projected/provided field names ([]string)
 	selectQuery := fmt.Sprintf("SELECT %v FROM %v ", strings.Join(tableFields, ", "), tableName)
 	// from / where condition (where-in-values)
-	whereIds := ""
-	idLen := len(recordIds)
-	for idCount, id := range recordIds {
-		whereIds += "'" + id + "'"
-		if idLen > 1 && idCount < idLen-1 {
-			whereIds += ", "
-		}
+	var fieldValues []interface{}
+	for _, id := range recordIds {
+		fieldValues = append(fieldValues, id)
 	}
-	selectQuery += fmt.Sprintf("WHERE id IN( %v )", whereIds)
-	return selectQuery, nil
+	whereQuery := fmt.Sprintf("WHERE id IN(%v)", ComputePlaceholders(1, len(recordIds)))
+	return types.SelectQueryResponseType{
+		SelectQuery: selectQuery + whereQuery,
+		WhereQuery:  whereQuery,
+		FieldValues: fieldValues,
+	}, nil
 }
 
 // ComputeSelectQueryByParam compose SELECT query from the where-parameters
-func ComputeSelectQueryByParam(tableName string, where types.QueryParamType, tableFields []string) (string, error) {
+// SelectQuery is the complete select script, with the where-script (WhereQuery) and value-placeholders for FieldValues
+func ComputeSelectQueryByParam(tableName string, where types.QueryParamType, tableFields []string) (types.SelectQueryResponseType, error) {
 	if tableName == "" || len(where) < 1 || len(tableFields) < 1 {
-		return "", errors.New("table-name, tableFields and where-params are required to perform the select operation")
+		return types.SelectQueryResponseType{}, errors.New("table-name, tableFields and where-params are required to perform the select operation")
 	}
-	// get record(s) based on projected/provided field names ([]string)
-	selectQuery := fmt.Sprintf("SELECT %v FROM %v ", strings.Join(tableFields, ", "), tableName)
 	// add where-params condition
-	if whereScript, err := ComputeWhereQuery(where); err == nil {
-		selectQuery += whereScript
-		return selectQuery, nil
-	} else {
-		return "", errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
+	whereRes, err := ComputeWhereQuery(where, 0)
+	if err != nil {
+		return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
+	}
+	fieldValues := whereRes.FieldValues
+	// get record(s) based on projected/provided field names ([]string), and the search-rank (virtual field), if specified
+	var selectFields []string
+	for _, fieldName := range tableFields {
+		if fieldName != types.SearchRankField {
+			selectFields = append(selectFields, fieldName)
+			continue
+		}
+		rankQuery, rankValues, err := ComputeSearchRankQuery(where, len(fieldValues)+1)
+		if err != nil {
+			return types.SelectQueryResponseType{}, err
+		}
+		if rankQuery == "" {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("%v field requires a search where-condition", types.SearchRankField))
+		}
+		selectFields = append(selectFields, fmt.Sprintf("%v AS %v", rankQuery, types.SearchRankField))
+		fieldValues = append(fieldValues, rankValues...)
+	}
+	selectQuery := fmt.Sprintf("SELECT %v FROM %v ", strings.Join(selectFields, ", "), tableName)
+	return types.SelectQueryResponseType{
+		SelectQuery: selectQuery + whereRes.WhereQuery,
+		WhereQuery:  whereRes.WhereQuery,
+		FieldValues: fieldValues,
+	}, nil
+}
+
+// ComputeSelectQueryRelation compose SELECT query for the relation's target-table records, with the target-field
+// values IN the sourceValues (source-field values of the source-table records), for eager loading.
+// The target-fields are the relation's TargetModel fields (all fields, if not specified)
+func ComputeSelectQueryRelation(relation mctypes.ModelRelationType, sourceValues []interface{}) (types.SelectQueryResponseType, error) {
+	if relation.TargetTable == "" || relation.TargetField == "" || len(sourceValues) < 1 {
+		return types.SelectQueryResponseType{}, errors.New("target-table, target-field and source-values are required to perform the relation select operation")
+	}
+	if !IsValidFieldName(relation.TargetTable) || !IsValidFieldName(relation.TargetField) {
+		return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("invalid relation target-table [%v] or target-field [%v]", relation.TargetTable, relation.TargetField))
+	}
+	// target-fields, sorted for a stable select script
+	var targetFields []string
+	for fieldName := range relation.TargetModel.RecordDesc {
+		if !IsValidFieldName(fieldName) {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("invalid relation target-model field-name [%v]", fieldName))
+		}
+		targetFields = append(targetFields, fieldName)
+	}
+	sort.Strings(targetFields)
+	selectFields := "*"
+	if len(targetFields) > 0 {
+		if !ArrayStringContains(targetFields, relation.TargetField) {
+			targetFields = append(targetFields, relation.TargetField)
+		}
+		selectFields = strings.Join(targetFields, ", ")
 	}
+	whereQuery := fmt.Sprintf("WHERE %v IN(%v)", relation.TargetField, ComputePlaceholders(1, len(sourceValues)))
+	return types.SelectQueryResponseType{
+		SelectQuery: fmt.Sprintf("SELECT %v FROM %v %v ORDER BY %v", selectFields, relation.TargetTable, whereQuery, relation.TargetField),
+		WhereQuery:  whereQuery,
+		FieldValues: sourceValues,
+	}, nil
 }
 
-// TODO: select-query functions for relational tables (eager & lazy queries) and data aggregation
+// TODO: lazy-loading select-query functions for relational tables
@@ -0,0 +1,69 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute select-SQL script test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestComputeSelectQuery(t *testing.T) {
+	tableFields := []string{"id", "name", "age"}
+	testCases := []struct {
+		name        string
+		recordIds   []string
+		where       types.QueryParamType
+		selectQuery string
+		fieldValues []interface{}
+		isError     bool
+	}{
+		{
+			name:        "should compute the select-by-id query, with the id placeholders:",
+			recordIds:   []string{"id-1", "id-2"},
+			selectQuery: "SELECT id, name, age FROM users WHERE id IN($1, $2)",
+			fieldValues: []interface{}{"id-1", "id-2"},
+		},
+		{
+			name: "should compute the select-by-param query, with the where placeholders:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi'; DROP TABLE users"}}, GroupItemOp: "and"},
+					{GroupItem: map[string]map[string]interface{}{"age": {"gte": 21}}, GroupItemOrder: 1},
+				}},
+			},
+			selectQuery: "SELECT id, name, age FROM users WHERE (name=$1 AND age>=$2)",
+			fieldValues: []interface{}{"abi'; DROP TABLE users", 21},
+		},
+		{
+			name: "should return an error for the invalid where field-name:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name; DROP TABLE users": {"eq": "abi"}}},
+				}},
+			},
+			isError: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				var res types.SelectQueryResponseType
+				var err error
+				if len(tc.recordIds) > 0 {
+					res, err = ComputeSelectQueryById("users", tc.recordIds, tableFields)
+				} else {
+					res, err = ComputeSelectQueryByParam("users", tc.where, tableFields)
+				}
+				mctest.AssertEquals(t, err != nil, tc.isError, "select-query error should be as expected")
+				mctest.AssertEquals(t, res.SelectQuery, tc.selectQuery, "select-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "select-query values should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
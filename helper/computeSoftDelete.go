@@ -0,0 +1,167 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute soft-delete, restore, purge and not-deleted (read) SQL scripts
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctypes/groupOperators"
+	"sort"
+	"strings"
+)
+
+// computeDeletedCondition function returns the where-script, for the soft-deleted (deleted) or not-deleted records,
+// combined with the where-script condition(s), if specified
+func computeDeletedCondition(whereQuery string, deleted bool) string {
+	deletedCondition := types.DeletedAtField + " IS NULL"
+	if deleted {
+		deletedCondition = types.DeletedAtField + " IS NOT NULL"
+	}
+	whereCondition := strings.TrimPrefix(strings.TrimSpace(whereQuery), "WHERE ")
+	if whereCondition == "" {
+		return "WHERE " + deletedCondition
+	}
+	return fmt.Sprintf("WHERE %v AND (%v)", deletedCondition, whereCondition)
+}
+
+// ComputeNotDeletedParams function composes the where-params for the not-deleted records, i.e. the deleted_at
+// isNull condition AND the where-params (groups linked by their groupLinkOp, as a nested group), if specified
+func ComputeNotDeletedParams(where types.QueryParamType) types.QueryParamType {
+	notDeletedItems := []types.QueryItemType{
+		{
+			GroupItem:      map[string]map[string]interface{}{types.DeletedAtField: {types.IsNull: true}},
+			GroupItemOrder: 0,
+			GroupItemOp:    groupOperators.AND,
+		},
+	}
+	if len(where) == 1 {
+		whereGroup := where[0]
+		notDeletedItems = append(notDeletedItems, types.QueryItemType{Group: &whereGroup, GroupItemOrder: 1})
+	} else if len(where) > 1 {
+		// sort (a copy of) where by groupOrder (ASC), as the nested group-items
+		groups := append(types.QueryParamType{}, where...)
+		sort.SliceStable(groups, func(i, j int) bool {
+			return groups[i].GroupOrder < groups[j].GroupOrder
+		})
+		var whereItems []types.QueryItemType
+		for groupNum := range groups {
+			whereItems = append(whereItems, types.QueryItemType{
+				Group:          &groups[groupNum],
+				GroupItemOrder: groupNum,
+				GroupItemOp:    groups[groupNum].GroupLinkOp,
+			})
+		}
+		notDeletedItems = append(notDeletedItems, types.QueryItemType{
+			Group:          &types.QueryGroupType{GroupName: "where", GroupItems: whereItems},
+			GroupItemOrder: 1,
+		})
+	}
+	return types.QueryParamType{
+		{
+			GroupName:  "not_deleted",
+			GroupItems: notDeletedItems,
+		},
+	}
+}
+
+// ComputeDeleteSnapshotQuery function computes the select-query (locked, FOR UPDATE) for the tableFields of the records
+// met by the delete-query (ComputeDeleteQueryById or ComputeDeleteQueryByParam) where-script, i.e. the records to
+// delete, or the not-deleted records to soft-delete, for the softDelete option
+func ComputeDeleteSnapshotQuery(tableName string, deleteQuery types.DeleteQueryResponseType, tableFields []string, softDelete bool) (types.SelectQueryResponseType, error) {
+	if tableName == "" || deleteQuery.WhereQuery == "" || len(tableFields) < 1 {
+		return types.SelectQueryResponseType{}, errors.New("table-name, table-fields and where-query condition are required for the delete-snapshot operation")
+	}
+	for _, fieldName := range tableFields {
+		if !IsValidFieldName(fieldName) {
+			return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("invalid field_name[%v]", fieldName))
+		}
+	}
+	whereQuery := deleteQuery.WhereQuery
+	if softDelete {
+		whereQuery = computeDeletedCondition(whereQuery, false)
+	}
+	return types.SelectQueryResponseType{
+		SelectQuery: fmt.Sprintf("SELECT %v FROM %v %v FOR UPDATE", strings.Join(tableFields, ", "), tableName, whereQuery),
+		WhereQuery:  whereQuery,
+		FieldValues: deleteQuery.FieldValues,
+	}, nil
+}
+
+// ComputeSoftDeleteQuery function computes the soft-delete SQL script, i.e. set the deleted_at/deleted_by fields of the
+// not-deleted records, for the delete-query (ComputeDeleteQueryById or ComputeDeleteQueryByParam) where-script
+func ComputeSoftDeleteQuery(tableName string, deleteQuery types.DeleteQueryResponseType, userId string) (types.DeleteQueryResponseType, error) {
+	if tableName == "" || deleteQuery.WhereQuery == "" {
+		return types.DeleteQueryResponseType{}, errors.New("table-name and where-query condition are required for the soft-delete operation")
+	}
+	var deletedBy interface{}
+	if userId != "" {
+		deletedBy = userId
+	}
+	fieldValues := append(append([]interface{}{}, deleteQuery.FieldValues...), deletedBy)
+	whereQuery := computeDeletedCondition(deleteQuery.WhereQuery, false)
+	return types.DeleteQueryResponseType{
+		DeleteQuery: fmt.Sprintf("UPDATE %v SET %v=CURRENT_TIMESTAMP, %v=$%v %v", tableName, types.DeletedAtField, types.DeletedByField, len(fieldValues), whereQuery),
+		WhereQuery:  whereQuery,
+		FieldValues: fieldValues,
+	}, nil
+}
+
+// ComputeSoftDeleteAllQuery function computes the soft-delete SQL script for all the not-deleted records of the table,
+// i.e. the soft-delete delete-all
+func ComputeSoftDeleteAllQuery(tableName string, userId string) (types.DeleteQueryResponseType, error) {
+	if tableName == "" {
+		return types.DeleteQueryResponseType{}, errors.New("table-name is required for the soft-delete-all operation")
+	}
+	var deletedBy interface{}
+	if userId != "" {
+		deletedBy = userId
+	}
+	whereQuery := computeDeletedCondition("", false)
+	return types.DeleteQueryResponseType{
+		DeleteQuery: fmt.Sprintf("UPDATE %v SET %v=CURRENT_TIMESTAMP, %v=$1 %v", tableName, types.DeletedAtField, types.DeletedByField, whereQuery),
+		WhereQuery:  whereQuery,
+		FieldValues: []interface{}{deletedBy},
+	}, nil
+}
+
+// ComputeNotDeletedUpdateQuery function composes the update-query (e.g. ComputeUpdateQuery) where-script with the
+// not-deleted condition, i.e. the soft-deleted records are not updated
+func ComputeNotDeletedUpdateQuery(updateQuery types.UpdateQueryResponseType) types.UpdateQueryResponseType {
+	whereQuery := computeDeletedCondition(updateQuery.WhereQuery, false)
+	return types.UpdateQueryResponseType{
+		UpdateQuery: strings.TrimSuffix(updateQuery.UpdateQuery, updateQuery.WhereQuery) + whereQuery,
+		WhereQuery:  whereQuery,
+		FieldValues: updateQuery.FieldValues,
+	}
+}
+
+// ComputeRestoreQuery function computes the restore SQL script, i.e. unset the deleted_at/deleted_by fields of the
+// soft-deleted records, for the delete-query (ComputeDeleteQueryById or ComputeDeleteQueryByParam) where-script
+func ComputeRestoreQuery(tableName string, deleteQuery types.DeleteQueryResponseType) (types.DeleteQueryResponseType, error) {
+	if tableName == "" || deleteQuery.WhereQuery == "" {
+		return types.DeleteQueryResponseType{}, errors.New("table-name and where-query condition are required for the restore operation")
+	}
+	whereQuery := computeDeletedCondition(deleteQuery.WhereQuery, true)
+	return types.DeleteQueryResponseType{
+		DeleteQuery: fmt.Sprintf("UPDATE %v SET %v=NULL, %v=NULL %v", tableName, types.DeletedAtField, types.DeletedByField, whereQuery),
+		WhereQuery:  whereQuery,
+		FieldValues: deleteQuery.FieldValues,
+	}, nil
+}
+
+// ComputePurgeQuery function computes the purge SQL script, i.e. delete the soft-deleted records, deleted more than
+// the specified number of days ago (all soft-deleted records, for 0 days)
+func ComputePurgeQuery(tableName string, days int) (types.DeleteQueryResponseType, error) {
+	if tableName == "" || days < 0 {
+		return types.DeleteQueryResponseType{}, errors.New("table-name and a non-negative number of days are required for the purge operation")
+	}
+	whereQuery := fmt.Sprintf("WHERE %v IS NOT NULL AND %v < CURRENT_TIMESTAMP - make_interval(days => $1)", types.DeletedAtField, types.DeletedAtField)
+	return types.DeleteQueryResponseType{
+		DeleteQuery: fmt.Sprintf("DELETE FROM %v %v", tableName, whereQuery),
+		WhereQuery:  whereQuery,
+		FieldValues: []interface{}{days},
+	}, nil
+}
@@ -0,0 +1,74 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute order-by/sort-SQL script
+
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"sort"
+	"strings"
+)
+
+// ComputeSortItems function transforms the sortParams (map) into sortItems, ordered by the field-names,
+// to guarantee the same order-by script for the same sortParams
+func ComputeSortItems(sortParams types.SortParamType) types.SortItemsType {
+	var sortItems types.SortItemsType
+	for fieldName, order := range sortParams {
+		sortItems = append(sortItems, types.SortItemType{
+			FieldName: fieldName,
+			Order:     order,
+		})
+	}
+	sort.SliceStable(sortItems, func(i, j int) bool {
+		return sortItems[i].FieldName < sortItems[j].FieldName
+	})
+	return sortItems
+}
+
+// ComputeSortQuery function computes the order-by script from the sortItems, in the specified priority order.
+// The sort field-names must be included in the allowedFields (i.e. projected/table-fields).
+// The id field is added as the last sort-field, to guarantee a stable/deterministic order for skip/limit paging,
+// and it is the default order-by, if no sortItems is specified
+func ComputeSortQuery(sortItems types.SortItemsType, allowedFields []string) (string, error) {
+	var orderItems []string
+	var sortFields []string
+	for _, item := range sortItems {
+		if item.FieldName == "" {
+			return "", errors.New("sort field-name is required")
+		}
+		if !ArrayStringContains(allowedFields, item.FieldName) {
+			return "", errors.New(fmt.Sprintf("sort field-name [%v] is not a projected/allowed field", item.FieldName))
+		}
+		if ArrayStringContains(sortFields, item.FieldName) {
+			return "", errors.New(fmt.Sprintf("sort field-name [%v] must be specified only once", item.FieldName))
+		}
+		sortFields = append(sortFields, item.FieldName)
+		orderItem := item.FieldName
+		switch item.Order {
+		case 1:
+			orderItem += " ASC"
+		case -1:
+			orderItem += " DESC"
+		default:
+			return "", errors.New(fmt.Sprintf("sort order for field-name [%v] must be 1 (asc) or -1 (desc)", item.FieldName))
+		}
+		switch strings.ToLower(item.Nulls) {
+		case "":
+		case "first":
+			orderItem += " NULLS FIRST"
+		case "last":
+			orderItem += " NULLS LAST"
+		default:
+			return "", errors.New(fmt.Sprintf("sort nulls for field-name [%v] must be first or last", item.FieldName))
+		}
+		orderItems = append(orderItems, orderItem)
+	}
+	// default/tie-breaker order by id
+	if !ArrayStringContains(sortFields, "id") {
+		orderItems = append(orderItems, "id ASC")
+	}
+	return "ORDER BY " + strings.Join(orderItems, ", "), nil
+}
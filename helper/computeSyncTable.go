@@ -1,22 +1,363 @@
-// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2020-12-08
+// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2026-10-18
 // @Company: mConnect.biz | @License: MIT
 // @Description: compute sync-table script / data migration actions
 
 package helper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mctypes"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"sort"
+	"strings"
 )
 
-func SyncTableQuery(model mctypes.ModelType, appDb *pgxpool.Pool) (string, error)  {
+// dbQueryer is the query interface of the db-pool and transaction, to read the table-schema
+type dbQueryer interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// liveColumnType is the existing (information_schema) table-column description
+type liveColumnType struct {
+	Name     string
+	DataType string
+	NotNull  bool
+}
+
+// liveTableType is the existing table-schema: columns, single-column unique constraints and index-names
+type liveTableType struct {
+	Columns     map[string]liveColumnType
+	ColumnNames []string          // in ordinal position
+	Uniques     map[string]string // column-name => constraint-name
+	Indexes     []string
+}
 
+// computeLiveColumnType function returns the column-type, in the ComputeColumnType format, for the
+// information_schema data_type, udt_name, character_maximum_length, numeric_precision and numeric_scale
+func computeLiveColumnType(dataType string, udtName string, charLength *int32, numericPrecision *int32, numericScale *int32) string {
+	switch dataType {
+	case "character varying":
+		if charLength != nil {
+			return fmt.Sprintf("VARCHAR(%v)", *charLength)
+		}
+		return "VARCHAR"
+	case "numeric":
+		if numericPrecision != nil {
+			scale := int32(0)
+			if numericScale != nil {
+				scale = *numericScale
+			}
+			return fmt.Sprintf("NUMERIC(%v,%v)", *numericPrecision, scale)
+		}
+		return "NUMERIC"
+	case "timestamp with time zone":
+		return "TIMESTAMPTZ"
+	case "timestamp without time zone":
+		return "TIMESTAMP"
+	case "time without time zone":
+		return "TIME"
+	case "ARRAY":
+		switch udtName {
+		case "_text":
+			return "TEXT[]"
+		case "_numeric":
+			return "NUMERIC[]"
+		case "_bool":
+			return "BOOLEAN[]"
+		}
+		return strings.ToUpper(strings.TrimPrefix(udtName, "_")) + "[]"
+	default:
+		return strings.ToUpper(dataType)
+	}
+}
 
-	return "", nil
+// columnTypeAliases maps the PostgreSQL column-type aliases to the ComputeColumnType type-names
+var columnTypeAliases = map[string]string{
+	"CHARACTER VARYING":           "VARCHAR",
+	"DECIMAL":                     "NUMERIC",
+	"INT":                         "INTEGER",
+	"INT4":                        "INTEGER",
+	"INT8":                        "BIGINT",
+	"FLOAT4":                      "REAL",
+	"FLOAT8":                      "DOUBLE PRECISION",
+	"BOOL":                        "BOOLEAN",
+	"TIMESTAMP WITH TIME ZONE":    "TIMESTAMPTZ",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
 }
 
-func SyncTable(model mctypes.ModelType, appDb *pgxpool.Pool) error {
+// normalizeColumnType function returns the column-type name (aliases resolved, see columnTypeAliases) and the
+// type-modifiers (e.g. the length, or the precision and scale, with the NUMERIC default scale 0), for the comparison
+// of the live and model column-types
+func normalizeColumnType(columnType string) (string, string) {
+	columnType = strings.ToUpper(strings.TrimSpace(columnType))
+	arraySuffix := ""
+	if strings.HasSuffix(columnType, "[]") {
+		arraySuffix = "[]"
+		columnType = strings.TrimSpace(strings.TrimSuffix(columnType, "[]"))
+	}
+	typeName, modifiers := columnType, ""
+	if start := strings.Index(columnType, "("); start >= 0 && strings.HasSuffix(columnType, ")") {
+		typeName = strings.TrimSpace(columnType[:start])
+		modifiers = strings.ReplaceAll(columnType[start+1:len(columnType)-1], " ", "")
+	}
+	if alias, ok := columnTypeAliases[typeName]; ok {
+		typeName = alias
+	}
+	if typeName == "NUMERIC" && modifiers != "" && !strings.Contains(modifiers, ",") {
+		modifiers += ",0"
+	}
+	return typeName + arraySuffix, modifiers
+}
 
+// columnTypeChanged function determines if the live column-type differs from the model column-type, i.e. the
+// (normalized) type-names differ, or the type-modifiers, if specified for both, differ. The type without modifiers
+// (e.g. NUMERIC or VARCHAR) is the same column-type as the type with modifiers (e.g. NUMERIC(10,2) or VARCHAR(255)).
+func columnTypeChanged(liveType string, modelType string) bool {
+	liveTypeName, liveModifiers := normalizeColumnType(liveType)
+	modelTypeName, modelModifiers := normalizeColumnType(modelType)
+	if liveTypeName != modelTypeName {
+		return true
+	}
+	return liveModifiers != "" && modelModifiers != "" && liveModifiers != modelModifiers
+}
+
+// readLiveTable function reads the existing table-schema, from the information_schema and pg_indexes,
+// in the current schema. The table does not exist, if no column is returned.
+func readLiveTable(db dbQueryer, tableName string) (liveTableType, error) {
+	ctx := context.Background()
+	liveTable := liveTableType{
+		Columns: map[string]liveColumnType{},
+		Uniques: map[string]string{},
+	}
+	// columns
+	rows, err := db.Query(ctx, "SELECT column_name, data_type, udt_name, character_maximum_length, numeric_precision, numeric_scale, is_nullable "+
+		"FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position", tableName)
+	if err != nil {
+		return liveTable, err
+	}
+	for rows.Next() {
+		var columnName, dataType, udtName, isNullable string
+		var charLength, numericPrecision, numericScale *int32
+		if err = rows.Scan(&columnName, &dataType, &udtName, &charLength, &numericPrecision, &numericScale, &isNullable); err != nil {
+			rows.Close()
+			return liveTable, err
+		}
+		liveTable.Columns[columnName] = liveColumnType{
+			Name:     columnName,
+			DataType: computeLiveColumnType(dataType, udtName, charLength, numericPrecision, numericScale),
+			NotNull:  isNullable == "NO",
+		}
+		liveTable.ColumnNames = append(liveTable.ColumnNames, columnName)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return liveTable, err
+	}
+	// single-column unique constraints
+	rows, err = db.Query(ctx, "SELECT tc.constraint_name, MIN(kcu.column_name::text) FROM information_schema.table_constraints tc "+
+		"JOIN information_schema.key_column_usage kcu ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name "+
+		"WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type = 'UNIQUE' "+
+		"GROUP BY tc.constraint_name HAVING COUNT(*) = 1", tableName)
+	if err != nil {
+		return liveTable, err
+	}
+	for rows.Next() {
+		var constraintName, columnName string
+		if err = rows.Scan(&constraintName, &columnName); err != nil {
+			rows.Close()
+			return liveTable, err
+		}
+		liveTable.Uniques[columnName] = constraintName
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return liveTable, err
+	}
+	// indexes
+	rows, err = db.Query(ctx, "SELECT indexname FROM pg_indexes WHERE schemaname = current_schema() AND tablename = $1", tableName)
+	if err != nil {
+		return liveTable, err
+	}
+	for rows.Next() {
+		var indexName string
+		if err = rows.Scan(&indexName); err != nil {
+			rows.Close()
+			return liveTable, err
+		}
+		liveTable.Indexes = append(liveTable.Indexes, indexName)
+	}
+	rows.Close()
+	return liveTable, rows.Err()
+}
 
-	return nil
-}
\ No newline at end of file
+// syncScriptType is the sync-table script and whether it is destructive (non-additive), i.e. it may lose data
+// or fail on the existing data: drop/re-create the table, drop columns, alter column types, set not-null,
+// drop unique constraints and drop indexes
+type syncScriptType struct {
+	script      string
+	destructive bool
+}
+
+// computeSyncTableScripts function computes the ordered scripts to sync the existing table to the model:
+// add columns, alter column types and nullability, add/drop unique constraints, create/drop indexes and drop columns.
+// The table is created, if it does not exist, or dropped and re-created (no data sync), if model.AlterSyncTable is
+// false and the Destructive option is set. The destructive scripts are returned as the skipped scripts, unless
+// the Destructive option is set. The soft-delete columns are synced, for the SoftDelete option. Only the indexes named by the model convention (tableName_fieldName_idx) are dropped;
+// primary key changes are not synced.
+func computeSyncTableScripts(model mctypes.ModelType, liveTable liveTableType, options types.TableOptionsType) (types.SyncTableResultType, error) {
+	result := types.SyncTableResultType{}
+	if len(liveTable.Columns) < 1 {
+		scripts, err := computeCreateTableScripts(model, options, false)
+		result.Scripts = scripts
+		return result, err
+	}
+	if !model.AlterSyncTable && options.Destructive {
+		createScripts, err := computeCreateTableScripts(model, options, false)
+		if err != nil {
+			return result, err
+		}
+		result.Scripts = append([]string{fmt.Sprintf("DROP TABLE %v", model.TableName)}, createScripts...)
+		return result, nil
+	}
+	columns, err := computeTableColumns(model, options)
+	if err != nil {
+		return result, err
+	}
+	tableName := model.TableName
+	var addScripts, alterScripts, uniqueScripts, indexScripts, dropScripts []syncScriptType
+	modelColumns := map[string]bool{}
+	for _, column := range columns {
+		modelColumns[column.Name] = true
+		liveColumn, ok := liveTable.Columns[column.Name]
+		if !ok {
+			if column.PrimaryKey {
+				return result, errors.New(fmt.Sprintf("primary key column [%v] change is not supported by the table sync", column.Name))
+			}
+			addScripts = append(addScripts, syncScriptType{script: fmt.Sprintf("ALTER TABLE %v ADD COLUMN %v", tableName, computeColumnScript(column))})
+		} else {
+			if columnTypeChanged(liveColumn.DataType, column.DataType) {
+				alterScripts = append(alterScripts, syncScriptType{
+					script:      fmt.Sprintf("ALTER TABLE %v ALTER COLUMN %v TYPE %v USING %v::%v", tableName, column.Name, column.DataType, column.Name, column.DataType),
+					destructive: true,
+				})
+			}
+			if liveColumn.NotNull != column.NotNull && !column.PrimaryKey {
+				if column.NotNull {
+					alterScripts = append(alterScripts, syncScriptType{
+						script:      fmt.Sprintf("ALTER TABLE %v ALTER COLUMN %v SET NOT NULL", tableName, column.Name),
+						destructive: true,
+					})
+				} else {
+					alterScripts = append(alterScripts, syncScriptType{script: fmt.Sprintf("ALTER TABLE %v ALTER COLUMN %v DROP NOT NULL", tableName, column.Name)})
+				}
+			}
+			constraintName, isUnique := liveTable.Uniques[column.Name]
+			if column.Unique && !isUnique {
+				uniqueScripts = append(uniqueScripts, syncScriptType{script: fmt.Sprintf("ALTER TABLE %v ADD CONSTRAINT %v_%v_key UNIQUE (%v)", tableName, tableName, column.Name, column.Name)})
+			} else if !column.Unique && isUnique {
+				uniqueScripts = append(uniqueScripts, syncScriptType{
+					script:      fmt.Sprintf("ALTER TABLE %v DROP CONSTRAINT %v", tableName, constraintName),
+					destructive: true,
+				})
+			}
+		}
+		indexName := computeIndexName(tableName, column.Name)
+		hasIndex := ArrayStringContains(liveTable.Indexes, indexName)
+		if column.Indexable && !column.PrimaryKey && !column.Unique {
+			if !hasIndex {
+				indexScripts = append(indexScripts, syncScriptType{script: computeIndexScript(tableName, column.Name)})
+			}
+		} else if hasIndex {
+			indexScripts = append(indexScripts, syncScriptType{script: fmt.Sprintf("DROP INDEX %v", indexName), destructive: true})
+		}
+	}
+	// dropped columns (and the model-convention indexes), in the column-name order
+	var dropColumns []string
+	for _, columnName := range liveTable.ColumnNames {
+		if !modelColumns[columnName] {
+			dropColumns = append(dropColumns, columnName)
+		}
+	}
+	sort.Strings(dropColumns)
+	for _, columnName := range dropColumns {
+		indexName := computeIndexName(tableName, columnName)
+		if ArrayStringContains(liveTable.Indexes, indexName) {
+			indexScripts = append(indexScripts, syncScriptType{script: fmt.Sprintf("DROP INDEX %v", indexName), destructive: true})
+		}
+		dropScripts = append(dropScripts, syncScriptType{script: fmt.Sprintf("ALTER TABLE %v DROP COLUMN %v", tableName, columnName), destructive: true})
+	}
+	for _, scripts := range [][]syncScriptType{addScripts, alterScripts, uniqueScripts, indexScripts, dropScripts} {
+		for _, script := range scripts {
+			if script.destructive && !options.Destructive {
+				result.SkippedScripts = append(result.SkippedScripts, script.script)
+			} else {
+				result.Scripts = append(result.Scripts, script.script)
+			}
+		}
+	}
+	return result, nil
+}
+
+// TableColumnNames function returns the column-names of the existing table (information_schema), in column order
+func TableColumnNames(db dbQueryer, tableName string) ([]string, error) {
+	liveTable, err := readLiveTable(db, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(liveTable.ColumnNames) < 1 {
+		return nil, errors.New(fmt.Sprintf("table [%v] does not exist", tableName))
+	}
+	return liveTable.ColumnNames, nil
+}
+
+// SyncTableQuery function computes the scripts (separated by ";\n") to sync the existing table to the model,
+// i.e. the sync plan (dry-run) of the additive changes or, for the Destructive option, all changes, see SyncTable
+func SyncTableQuery(model mctypes.ModelType, appDb *pgxpool.Pool, options types.TableOptionsType) (string, error) {
+	options.DryRun = true
+	result, err := SyncTable(model, appDb, options)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(result.Scripts, ";\n"), nil
+}
+
+// SyncTable function syncs the existing table (information_schema) to the model, in a transaction, and returns the
+// ordered sync scripts/plan: add columns, alter column types and nullability, add/drop unique constraints,
+// create/drop indexes and drop columns. By default, only the additive changes (add columns, drop not-null,
+// add unique constraints and create indexes) are applied, and the destructive changes are reported (SkippedScripts).
+// The Destructive option applies all the changes, including the drop and re-create of the table (no data sync),
+// if model.AlterSyncTable is false. The plan is returned without applying it, for the DryRun option.
+func SyncTable(model mctypes.ModelType, appDb *pgxpool.Pool, options types.TableOptionsType) (types.SyncTableResultType, error) {
+	if appDb == nil {
+		return types.SyncTableResultType{}, errors.New("db connection is required to sync the table")
+	}
+	if model.TableName == "" || !IsValidFieldName(model.TableName) {
+		return types.SyncTableResultType{}, errors.New(fmt.Sprintf("invalid table-name [%v]", model.TableName))
+	}
+	tx, txErr := appDb.Begin(context.Background())
+	if txErr != nil {
+		return types.SyncTableResultType{}, txErr
+	}
+	defer tx.Rollback(context.Background())
+	liveTable, err := readLiveTable(tx, model.TableName)
+	if err != nil {
+		return types.SyncTableResultType{}, errors.New(fmt.Sprintf("error reading the table [%v] schema: %v", model.TableName, err.Error()))
+	}
+	result, err := computeSyncTableScripts(model, liveTable, options)
+	if err != nil {
+		return result, err
+	}
+	if options.DryRun || len(result.Scripts) < 1 {
+		return result, nil
+	}
+	for _, script := range result.Scripts {
+		if _, err := tx.Exec(context.Background(), script); err != nil {
+			return result, errors.New(fmt.Sprintf("error executing script [%v]: %v", script, err.Error()))
+		}
+	}
+	return result, tx.Commit(context.Background())
+}
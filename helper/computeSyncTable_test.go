@@ -0,0 +1,211 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute sync-table and alter-table scripts test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/datatypes"
+	"testing"
+)
+
+func TestSyncTableScripts(t *testing.T) {
+	model := mctypes.ModelType{
+		TableName: "users",
+		RecordDesc: mctypes.RecordDescType{
+			"name":  {FieldType: datatypes.String, FieldLength: 100},
+			"age":   {FieldType: datatypes.Integer, AllowNull: true},
+			"email": {FieldType: datatypes.Email, Unique: true},
+			"city":  {FieldType: datatypes.String, Indexable: true},
+		},
+		AlterSyncTable: true,
+	}
+	// existing table: name (shorter and nullable), age (not null), email (not unique), old (indexed), without city
+	liveTable := liveTableType{
+		Columns: map[string]liveColumnType{
+			"id":    {Name: "id", DataType: "UUID", NotNull: true},
+			"name":  {Name: "name", DataType: "VARCHAR(50)"},
+			"age":   {Name: "age", DataType: "INTEGER", NotNull: true},
+			"email": {Name: "email", DataType: "VARCHAR(255)", NotNull: true},
+			"old":   {Name: "old", DataType: "VARCHAR(255)"},
+		},
+		ColumnNames: []string{"id", "name", "age", "email", "old"},
+		Uniques:     map[string]string{},
+		Indexes:     []string{"users_pkey", "users_old_idx"},
+	}
+	// existing table, in sync with the model
+	syncedTable := liveTableType{
+		Columns: map[string]liveColumnType{
+			"id":    {Name: "id", DataType: "UUID", NotNull: true},
+			"name":  {Name: "name", DataType: "VARCHAR(100)", NotNull: true},
+			"age":   {Name: "age", DataType: "INTEGER"},
+			"email": {Name: "email", DataType: "VARCHAR(255)", NotNull: true},
+			"city":  {Name: "city", DataType: "VARCHAR(255)", NotNull: true},
+		},
+		ColumnNames: []string{"id", "name", "age", "email", "city"},
+		Uniques:     map[string]string{"email": "users_email_key"},
+		Indexes:     []string{"users_pkey", "users_email_key", "users_city_idx"},
+	}
+	createScripts := []string{
+		"CREATE TABLE users (id UUID NOT NULL DEFAULT gen_random_uuid(), age INTEGER, city VARCHAR(255) NOT NULL, " +
+			"email VARCHAR(255) NOT NULL UNIQUE, name VARCHAR(100) NOT NULL, PRIMARY KEY (id))",
+		"CREATE INDEX IF NOT EXISTS users_city_idx ON users (city)",
+	}
+	additiveScripts := []string{
+		"ALTER TABLE users ADD COLUMN city VARCHAR(255) NOT NULL",
+		"ALTER TABLE users ALTER COLUMN age DROP NOT NULL",
+		"ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email)",
+		"CREATE INDEX IF NOT EXISTS users_city_idx ON users (city)",
+	}
+	destructiveScripts := []string{
+		"ALTER TABLE users ALTER COLUMN name TYPE VARCHAR(100) USING name::VARCHAR(100)",
+		"ALTER TABLE users ALTER COLUMN name SET NOT NULL",
+		"DROP INDEX users_old_idx",
+		"ALTER TABLE users DROP COLUMN old",
+	}
+	recreateModel := model
+	recreateModel.AlterSyncTable = false
+	// existing table, in sync with the typesModel, except the age column-type (INTEGER): the column-types differ
+	// by the type-modifiers (precision/scale and length) and the type-aliases only
+	typesModel := mctypes.ModelType{
+		TableName: "prices",
+		RecordDesc: mctypes.RecordDescType{
+			"price": {FieldType: datatypes.Number},
+			"name":  {FieldType: datatypes.String, FieldLength: 100},
+			"score": {FieldType: datatypes.Float},
+			"age":   {FieldType: datatypes.BigInt},
+		},
+		AlterSyncTable: true,
+	}
+	typesTable := liveTableType{
+		Columns: map[string]liveColumnType{
+			"id":    {Name: "id", DataType: "UUID", NotNull: true},
+			"price": {Name: "price", DataType: "NUMERIC(10,2)", NotNull: true},
+			"name":  {Name: "name", DataType: "VARCHAR", NotNull: true},
+			"score": {Name: "score", DataType: "FLOAT8", NotNull: true},
+			"age":   {Name: "age", DataType: "INTEGER", NotNull: true},
+		},
+		ColumnNames: []string{"id", "price", "name", "score", "age"},
+		Uniques:     map[string]string{},
+		Indexes:     []string{"prices_pkey"},
+	}
+	testCases := []struct {
+		name           string
+		model          mctypes.ModelType
+		liveTable      liveTableType
+		options        types.TableOptionsType
+		scripts        []string
+		skippedScripts []string
+	}{
+		{
+			name:      "should create the table, if it does not exist:",
+			model:     model,
+			liveTable: liveTableType{},
+			scripts:   createScripts,
+		},
+		{
+			name:           "should plan the additive changes and skip the destructive changes:",
+			model:          model,
+			liveTable:      liveTable,
+			scripts:        additiveScripts,
+			skippedScripts: destructiveScripts,
+		},
+		{
+			name:      "should plan all the changes, in order, for the destructive option:",
+			model:     model,
+			liveTable: liveTable,
+			options:   types.TableOptionsType{Destructive: true},
+			scripts: []string{
+				additiveScripts[0],
+				additiveScripts[1],
+				destructiveScripts[0],
+				destructiveScripts[1],
+				additiveScripts[2],
+				additiveScripts[3],
+				destructiveScripts[2],
+				destructiveScripts[3],
+			},
+		},
+		{
+			name:      "should drop and re-create the table, without the alter-sync-table, for the destructive option:",
+			model:     recreateModel,
+			liveTable: liveTable,
+			options:   types.TableOptionsType{Destructive: true},
+			scripts:   append([]string{"DROP TABLE users"}, createScripts...),
+		},
+		{
+			name:      "should plan no change, for the table in sync with the model:",
+			model:     model,
+			liveTable: syncedTable,
+		},
+		{
+			name:           "should plan the column-type change only, not the type-modifiers and type-aliases differences:",
+			model:          typesModel,
+			liveTable:      typesTable,
+			skippedScripts: []string{"ALTER TABLE prices ALTER COLUMN age TYPE BIGINT USING age::BIGINT"},
+		},
+		{
+			name:      "should add the soft-delete columns, for the soft-delete option:",
+			model:     model,
+			liveTable: syncedTable,
+			options:   types.TableOptionsType{SoftDelete: true},
+			scripts: []string{
+				"ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ",
+				"ALTER TABLE users ADD COLUMN deleted_by VARCHAR(255)",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := computeSyncTableScripts(tc.model, tc.liveTable, tc.options)
+				mctest.AssertEquals(t, err, nil, "sync-table error should be nil")
+				mctest.AssertStrictEquals(t, res.Scripts, tc.scripts, "sync-table scripts should be as expected")
+				mctest.AssertStrictEquals(t, res.SkippedScripts, tc.skippedScripts, "sync-table skipped scripts should be as expected")
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the create-table-if-not-exists and add-column-if-not-exists scripts:",
+		TestFunc: func() {
+			alterQuery, err := CreateAlterTableQuery(model)
+			mctest.AssertEquals(t, err, nil, "create-alter-table error should be nil")
+			mctest.AssertEquals(t, alterQuery, "CREATE TABLE IF NOT EXISTS users (id UUID NOT NULL DEFAULT gen_random_uuid(), "+
+				"age INTEGER, city VARCHAR(255) NOT NULL, email VARCHAR(255) NOT NULL UNIQUE, name VARCHAR(100) NOT NULL, PRIMARY KEY (id));\n"+
+				"ALTER TABLE users ADD COLUMN IF NOT EXISTS age INTEGER;\n"+
+				"ALTER TABLE users ADD COLUMN IF NOT EXISTS city VARCHAR(255) NOT NULL;\n"+
+				"ALTER TABLE users ADD COLUMN IF NOT EXISTS email VARCHAR(255) NOT NULL UNIQUE;\n"+
+				"ALTER TABLE users ADD COLUMN IF NOT EXISTS name VARCHAR(100) NOT NULL;\n"+
+				"CREATE INDEX IF NOT EXISTS users_city_idx ON users (city)", "create-alter-table scripts should be as expected")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the live column-types in the model column-type format:",
+		TestFunc: func() {
+			charLength := int32(100)
+			numericPrecision, numericScale := int32(10), int32(2)
+			mctest.AssertEquals(t, computeLiveColumnType("character varying", "varchar", &charLength, nil, nil), "VARCHAR(100)", "varchar column-type should be as expected")
+			mctest.AssertEquals(t, computeLiveColumnType("numeric", "numeric", nil, &numericPrecision, &numericScale), "NUMERIC(10,2)", "numeric column-type should be as expected")
+			mctest.AssertEquals(t, computeLiveColumnType("numeric", "numeric", nil, nil, nil), "NUMERIC", "unconstrained numeric column-type should be as expected")
+			mctest.AssertEquals(t, computeLiveColumnType("timestamp with time zone", "timestamptz", nil, nil, nil), "TIMESTAMPTZ", "timestamptz column-type should be as expected")
+			mctest.AssertEquals(t, computeLiveColumnType("ARRAY", "_text", nil, nil, nil), "TEXT[]", "text-array column-type should be as expected")
+			mctest.AssertEquals(t, computeLiveColumnType("double precision", "float8", nil, nil, nil), "DOUBLE PRECISION", "double precision column-type should be as expected")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compare the normalized column-types:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, columnTypeChanged("NUMERIC(10)", "numeric(10, 0)"), false, "numeric default scale should be the same column-type")
+			mctest.AssertEquals(t, columnTypeChanged("NUMERIC(10,2)", "NUMERIC(12,2)"), true, "numeric precision change should be a column-type change")
+			mctest.AssertEquals(t, columnTypeChanged("CHARACTER VARYING(255)", "VARCHAR(255)"), false, "varchar alias should be the same column-type")
+			mctest.AssertEquals(t, columnTypeChanged("VARCHAR(50)[]", "VARCHAR(100)[]"), true, "varchar-array length change should be a column-type change")
+			mctest.AssertEquals(t, columnTypeChanged("TEXT", "VARCHAR(255)"), true, "text and varchar should be different column-types")
+		},
+	})
+	mctest.PostTestResult()
+}
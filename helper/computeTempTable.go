@@ -1,22 +1,112 @@
-// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2020-12-08
+// @Author: abbeymart | Abi Akindele | @Created: 2020-12-08 | @Updated: 2026-10-18
 // @Company: mConnect.biz | @License: MIT
 // @Description: compute temporary-table script
 
 package helper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mctypes"
-	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4"
+	"sort"
+	"strings"
 )
 
-func CreateTempTableQuery(model mctypes.ModelType, appDb *pgxpool.Pool) (string, error) {
-
+// CreateTempTableQuery function computes the create-temporary-table script, from the model columns' types and defaults,
+// without the constraints (not null, unique and primary key), for a staging/bulk-load table.
+// The temp-table is session-scoped and dropped at the end of the transaction (ON COMMIT DROP).
+func CreateTempTableQuery(model mctypes.ModelType, tempTableName string) (string, error) {
+	if !IsValidFieldName(tempTableName) {
+		return "", errors.New(fmt.Sprintf("invalid temp-table-name [%v]", tempTableName))
+	}
+	columns, err := computeTableColumns(model, types.TableOptionsType{})
+	if err != nil {
+		return "", err
+	}
+	var columnScripts []string
+	for _, column := range columns {
+		columnScripts = append(columnScripts, computeColumnScript(tableColumnType{
+			Name:     column.Name,
+			DataType: column.DataType,
+			Default:  column.Default,
+		}))
+	}
+	return fmt.Sprintf("CREATE TEMP TABLE %v (%v) ON COMMIT DROP", tempTableName, strings.Join(columnScripts, ", ")), nil
+}
 
-	return "", nil
+// CreateTempTableLikeQuery function computes the create-temporary-table script, from the existing table columns
+// and defaults, for a staging/bulk-load table, if the table model is not available. The temp-table is dropped at
+// the end of the transaction (ON COMMIT DROP).
+func CreateTempTableLikeQuery(tableName string, tempTableName string) (string, error) {
+	if !IsValidFieldName(tableName) || !IsValidFieldName(tempTableName) {
+		return "", errors.New(fmt.Sprintf("invalid table-name [%v] or temp-table-name [%v]", tableName, tempTableName))
+	}
+	return fmt.Sprintf("CREATE TEMP TABLE %v (LIKE %v INCLUDING DEFAULTS) ON COMMIT DROP", tempTableName, tableName), nil
 }
 
-func CreateTempTable(model mctypes.ModelType, appDb *pgxpool.Pool) error {
+// CreateTempTableAsQuery function computes the create-temporary-table script, with the table columns' types only
+// (no defaults or constraints) for the tableFields, for a copy/bulk-load table. The temp-table is dropped at the end
+// of the transaction (ON COMMIT DROP).
+func CreateTempTableAsQuery(tableName string, tempTableName string, tableFields []string) (string, error) {
+	if !IsValidFieldName(tableName) || !IsValidFieldName(tempTableName) || len(tableFields) < 1 {
+		return "", errors.New("valid table-name, temp-table-name and table-fields are required for the temp-table")
+	}
+	for _, fieldName := range tableFields {
+		if !IsValidFieldName(fieldName) {
+			return "", errors.New(fmt.Sprintf("invalid field_name[%v]", fieldName))
+		}
+	}
+	return fmt.Sprintf("CREATE TEMP TABLE %v ON COMMIT DROP AS SELECT %v FROM %v WITH NO DATA", tempTableName, strings.Join(tableFields, ", "), tableName), nil
+}
 
+// CreateTempTable function creates the temporary-table, from the model, bound to the transaction (dropped on commit/rollback)
+func CreateTempTable(ctx context.Context, tx pgx.Tx, model mctypes.ModelType, tempTableName string) error {
+	createQuery, err := CreateTempTableQuery(model, tempTableName)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, createQuery)
+	return err
+}
 
-	return nil
+// ComputeTempTableInsertQuery function computes the insert-select script, from the temp-table into the table, for the
+// tableFields and the stamps (e.g. created_at/created_by, as the $n placeholder values, in place of the loaded
+// values), with the optional on-conflict script (see ComputeOnConflictQuery), returning the record ids
+func ComputeTempTableInsertQuery(tableName string, tempTableName string, tableFields []string, stamps types.ActionParamType, onConflictQuery string) (string, []interface{}, error) {
+	if !IsValidFieldName(tableName) || !IsValidFieldName(tempTableName) || len(tableFields) < 1 {
+		return "", nil, errors.New("valid table-name, temp-table-name and table-fields are required for the insert-select operation")
+	}
+	var insertFields, selectFields []string
+	for _, fieldName := range tableFields {
+		if !IsValidFieldName(fieldName) {
+			return "", nil, errors.New(fmt.Sprintf("invalid field_name[%v]", fieldName))
+		}
+		if _, ok := stamps[fieldName]; !ok {
+			insertFields = append(insertFields, fieldName)
+			selectFields = append(selectFields, fieldName)
+		}
+	}
+	// stamp fields, sorted for a stable script
+	var stampFields []string
+	for fieldName := range stamps {
+		if !IsValidFieldName(fieldName) {
+			return "", nil, errors.New(fmt.Sprintf("invalid stamp field_name[%v]", fieldName))
+		}
+		stampFields = append(stampFields, fieldName)
+	}
+	sort.Strings(stampFields)
+	var fieldValues []interface{}
+	for _, fieldName := range stampFields {
+		fieldValues = append(fieldValues, stamps[fieldName])
+		insertFields = append(insertFields, fieldName)
+		selectFields = append(selectFields, fmt.Sprintf("$%v", len(fieldValues)))
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %v(%v) SELECT %v FROM %v", tableName, strings.Join(insertFields, ", "), strings.Join(selectFields, ", "), tempTableName)
+	if onConflictQuery != "" {
+		insertQuery += " " + onConflictQuery
+	}
+	return insertQuery + " RETURNING id", fieldValues, nil
 }
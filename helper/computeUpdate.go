@@ -5,18 +5,15 @@
 package helper
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/abbeymart/mccrud/types"
-	"github.com/asaskevich/govalidator"
+	"strings"
 	"time"
 )
 
-func ComputeUpdateQuery(tableName string, actionParams types.ActionParamsType, tableFields []string) ([]string, error) {
-	if tableName == "" || len(actionParams) < 1 {
-		return nil, errors.New("table-name and action-params are required for the update operation")
-	}
+// computeUpdateFields function returns the tableFields or, if not specified, the update-fields from the first record
+func computeUpdateFields(actionParams types.ActionParamsType, tableFields []string) []string {
 	// compute tableFields from the first record, if len(tableFields) == 0
 	if len(tableFields) == 0 {
 		actRec := actionParams[0]
@@ -27,265 +24,139 @@ func ComputeUpdateQuery(tableName string, actionParams types.ActionParamsType, t
 			tableFields = append(tableFields, fName)
 		}
 	}
-	// compute update script from queryParams
-	var updateQuery []string
-	validUpdateItemCount := 0
-	invalidUpdateItemCount := 0
-
-	for recNum, rec := range actionParams {
-		itemScript := fmt.Sprintf("UPDATE %v SET", tableName)
-		fieldCount := 0
-		fieldLen := len(tableFields)
-		for _, fieldName := range tableFields {
-			fieldValue, ok := rec[fieldName]
-			// check for the required fields in each record
-			if !ok {
-				return nil, errors.New(fmt.Sprintf("Record #%v [%#v]: required field_name[%v] is missing", recNum, rec, fieldName))
-			}
-			fieldCount += 1
-			// update/set recFieldValues by fieldValue-type
-			var currentFieldValue interface{}
-			switch fieldValue.(type) {
-			case time.Time:
-				if fVal, ok := fieldValue.(time.Time); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					currentFieldValue = "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-				}
-			case string:
-				if fVal, ok := fieldValue.(string); !ok {
-					return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-				} else {
-					if govalidator.IsJSON(fVal) {
-						if fValue, err := govalidator.ToJSON(fieldValue); err != nil {
-							return nil, errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-						} else {
-							currentFieldValue = "'" + fValue + "'"
-						}
-					} else {
-						currentFieldValue = "'" + fVal + "'"
-					}
-				}
-			case int, uint, float64, bool:
-				currentFieldValue = fieldValue
-			default:
-				// json-stringify fieldValue
-				if fVal, err := json.Marshal(fieldValue); err != nil {
-					return nil, errors.New(fmt.Sprintf("Unknown or Unsupported field-value type: %v", err.Error()))
-				} else {
-					currentFieldValue = "'" + string(fVal) + "'"
-				}
-			}
-
-			// add itemValue
-			itemScript += fmt.Sprintf(" %v=%v", fieldName, currentFieldValue)
-			if fieldLen > 1 && fieldCount < fieldLen {
-				itemScript += ", "
-			}
-		}
-
-		// add where condition by id
-		itemScript += fmt.Sprintf(" WHERE id='%v'", rec["id"])
-		//validate/update script content based on valid field specifications
-		if fieldCount > 0 && fieldCount == fieldLen {
-			validUpdateItemCount += 1
-			updateQuery = append(updateQuery, itemScript)
-		} else {
-			invalidUpdateItemCount += 1
-		}
-	}
-	// check is there was invalid update items
-	if invalidUpdateItemCount > 0 {
-		return nil, errors.New(fmt.Sprintf("Invalid action-params [%v]", invalidUpdateItemCount))
-	}
-	return updateQuery, nil
+	return tableFields
 }
 
-func ComputeUpdateQueryById(tableName string, actionParams types.ActionParamsType, recordIds []string, tableFields []string) (string, error) {
-	if tableName == "" || len(actionParams) < 1 || len(recordIds) < 1 {
-		return "", errors.New("table-name, table-fields, action-params and record/doc-Ids are required for the update-by-id operation")
-	}
-	// compute tableFields from the first record, if len(tableFields) == 0
-	if len(tableFields) == 0 {
-		actRec := actionParams[0]
-		for fName := range actRec {
-			if fName == "id" {
-				continue
-			}
-			tableFields = append(tableFields, fName)
-		}
-	}
-	// compute update script from query-ids
-	var updateQuery string
-	itemScript := fmt.Sprintf("UPDATE %v SET", tableName)
-	// from / where condition (where-in-values)
-	whereIds := ""
-	idLen := len(recordIds)
-	for idCount, id := range recordIds {
-		whereIds += "'" + id + "'"
-		if idLen > 1 && idCount < idLen-1 {
-			whereIds += ", "
-		}
-	}
-	whereQuery := fmt.Sprintf(" WHERE id IN(%v)", whereIds)
-
-	invalidUpdateItemCount := 0
-	validUpdateItemCount := 0
-
-	// only one actionParams record is required for update by docIds
-	rec := actionParams[0]
-	fieldCount := 0
-	fieldLen := len(tableFields)
+// computeSetScript function computes the SET-script, with value-placeholders ($1...$n), for the rec/record
+// tableFields and returns the script and the placeholder values
+func computeSetScript(rec types.ActionParamType, tableFields []string) (string, []interface{}, error) {
+	var setItems []string
+	var fieldValues []interface{}
 	for _, fieldName := range tableFields {
+		if !IsValidFieldName(fieldName) {
+			return "", nil, errors.New(fmt.Sprintf("invalid field_name[%v]", fieldName))
+		}
 		fieldValue, ok := rec[fieldName]
 		// check for the required fields in each record
 		if !ok {
-			return "", errors.New(fmt.Sprintf("Record [%#v]: required field_name[%v] is missing", rec, fieldName))
+			return "", nil, errors.New(fmt.Sprintf("Record [%#v]: required field_name[%v] is missing", rec, fieldName))
 		}
-		fieldCount += 1
 		// update/set recFieldValues by fieldValue-type
-		var currentFieldValue interface{}
-		switch fieldValue.(type) {
-		case time.Time:
-			if fVal, ok := fieldValue.(time.Time); !ok {
-				return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-			} else {
-				currentFieldValue = "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-			}
-		case string:
-			if fVal, ok := fieldValue.(string); !ok {
-				return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-			} else {
-				if govalidator.IsJSON(fVal) {
-					if fValue, err := govalidator.ToJSON(fieldValue); err != nil {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue = "'" + fValue + "'"
-					}
-				} else {
-					currentFieldValue = "'" + fVal + "'"
-				}
-			}
-		case int, uint, float64, bool:
-			currentFieldValue = fieldValue
-		default:
-			// json-stringify fieldValue
-			if fVal, err := json.Marshal(fieldValue); err != nil {
-				return "", errors.New(fmt.Sprintf("Unknown or Unsupported field-value type: %v", err.Error()))
-			} else {
-				currentFieldValue = "'" + string(fVal) + "'"
-			}
-		}
-		// add itemValue
-		itemScript += fmt.Sprintf(" %v=%v", fieldName, currentFieldValue)
-		if fieldLen > 1 && fieldCount < fieldLen {
-			itemScript += ", "
+		currentFieldValue, err := ComputeFieldValue(fieldName, fieldValue)
+		if err != nil {
+			return "", nil, err
 		}
+		fieldValues = append(fieldValues, currentFieldValue)
+		// add itemValue placeholder
+		setItems = append(setItems, fmt.Sprintf("%v=$%v", fieldName, len(fieldValues)))
 	}
-	//validate/update script content based on valid field specifications
-	if fieldCount > 0 && fieldCount == fieldLen {
-		validUpdateItemCount += 1
-		updateQuery = itemScript + whereQuery
-	} else {
-		invalidUpdateItemCount += 1
-	}
-
-	// check is there was invalid update items
-	if invalidUpdateItemCount > 0 {
-		return "", errors.New(fmt.Sprintf("Invalid action-params [%v]", invalidUpdateItemCount))
+	if len(setItems) < 1 {
+		return "", nil, errors.New("Invalid action-params: no update field(s) specified")
 	}
-	return updateQuery, nil
+	return " " + strings.Join(setItems, ", "), fieldValues, nil
 }
 
-func ComputeUpdateQueryByParam(tableName string, actionParams types.ActionParamsType, where types.QueryParamType, tableFields []string) (string, error) {
-	if tableName == "" || len(actionParams) < 1 || len(where) < 1 {
-		return "", errors.New("table-name, action-params and where-params are required for the update-by-params operation")
+// ComputeUpdateQuery function computes the update-script, by record-id, for each of the actionParams' records.
+// Optimistic concurrency control (versionField, optional): the record versionField-value (as read) is required and
+// added to the where condition, and the versionField is incremented (numeric value) or set to the CURRENT_TIMESTAMP
+// (time/string value, e.g. updated_at). The update of a stale record affects no row.
+func ComputeUpdateQuery(tableName string, actionParams types.ActionParamsType, tableFields []string, versionField string) ([]types.UpdateQueryResponseType, error) {
+	if tableName == "" || len(actionParams) < 1 {
+		return nil, errors.New("table-name and action-params are required for the update operation")
 	}
-	// compute tableFields from the first record, if len(tableFields) == 0
-	if len(tableFields) == 0 {
-		actRec := actionParams[0]
-		for fName := range actRec {
-			if fName == "id" {
-				continue
+	if versionField != "" && !IsValidFieldName(versionField) {
+		return nil, errors.New(fmt.Sprintf("invalid version field_name[%v]", versionField))
+	}
+	tableFields = computeUpdateFields(actionParams, tableFields)
+	if versionField != "" {
+		// the versionField is set by the update-script
+		var fields []string
+		for _, fieldName := range tableFields {
+			if fieldName != versionField {
+				fields = append(fields, fieldName)
 			}
-			tableFields = append(tableFields, fName)
 		}
+		tableFields = fields
 	}
-
 	// compute update script from queryParams
-	var updateQuery string
-	invalidUpdateItemCount := 0
-	validUpdateItemCount := 0
-
-	// only one actionParams record is required for update by where-params
-	rec := actionParams[0]
-	itemScript := fmt.Sprintf("UPDATE %v SET", tableName)
-	fieldCount := 0
-	fieldLen := len(tableFields)
-	for _, fieldName := range tableFields {
-		fieldValue, ok := rec[fieldName]
-		// check for the required fields in each record
-		if !ok {
-			return "", errors.New(fmt.Sprintf("Record [%#v]: required field_name[%v] is missing", rec, fieldName))
+	var updateQuery []types.UpdateQueryResponseType
+	for recNum, rec := range actionParams {
+		setScript, fieldValues, err := computeSetScript(rec, tableFields)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Record #%v: %v", recNum, err.Error()))
 		}
-		fieldCount += 1
-		// update/set recFieldValues by fieldValue-type
-		var currentFieldValue interface{}
-		switch fieldValue.(type) {
-		case time.Time:
-			if fVal, ok := fieldValue.(time.Time); !ok {
-				return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-			} else {
-				currentFieldValue = "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-			}
-		case string:
-			if fVal, ok := fieldValue.(string); !ok {
-				return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-			} else {
-				if govalidator.IsJSON(fVal) {
-					if fValue, err := govalidator.ToJSON(fieldValue); err != nil {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue = "'" + fValue + "'"
-					}
-				} else {
-					currentFieldValue = "'" + fVal + "'"
-				}
+		recId, ok := rec["id"]
+		if !ok || recId == "" {
+			return nil, errors.New(fmt.Sprintf("Record #%v [%#v]: required field_name[id] is missing", recNum, rec))
+		}
+		// add where condition by id
+		fieldValues = append(fieldValues, recId)
+		whereQuery := fmt.Sprintf("WHERE id=$%v", len(fieldValues))
+		if versionField != "" {
+			versionValue, ok := rec[versionField]
+			if !ok || versionValue == nil {
+				return nil, errors.New(fmt.Sprintf("Record #%v [%#v]: required version field_name[%v] is missing", recNum, rec, versionField))
 			}
-		case int, uint, float64, bool:
-			currentFieldValue = fieldValue
-		default:
-			// json-stringify fieldValue
-			if fVal, err := json.Marshal(fieldValue); err != nil {
-				return "", errors.New(fmt.Sprintf("Unknown or Unsupported field-value type: %v", err.Error()))
-			} else {
-				currentFieldValue = "'" + string(fVal) + "'"
+			switch versionValue.(type) {
+			case time.Time, string:
+				setScript += fmt.Sprintf(", %v=CURRENT_TIMESTAMP", versionField)
+			default:
+				setScript += fmt.Sprintf(", %v=%v + 1", versionField, versionField)
 			}
+			fieldValues = append(fieldValues, versionValue)
+			whereQuery += fmt.Sprintf(" AND %v=$%v", versionField, len(fieldValues))
 		}
-		// add itemValue
-		itemScript += fmt.Sprintf(" %v=%v", fieldName, currentFieldValue)
+		updateQuery = append(updateQuery, types.UpdateQueryResponseType{
+			UpdateQuery: fmt.Sprintf("UPDATE %v SET%v %v", tableName, setScript, whereQuery),
+			WhereQuery:  whereQuery,
+			FieldValues: fieldValues,
+		})
+	}
+	return updateQuery, nil
+}
 
-		if fieldLen > 1 && fieldCount < fieldLen {
-			itemScript += ", "
-		}
+// ComputeUpdateQueryById function computes the update-script for the records that met the specified record-ids
+func ComputeUpdateQueryById(tableName string, actionParams types.ActionParamsType, recordIds []string, tableFields []string) (types.UpdateQueryResponseType, error) {
+	if tableName == "" || len(actionParams) < 1 || len(recordIds) < 1 {
+		return types.UpdateQueryResponseType{}, errors.New("table-name, table-fields, action-params and record/doc-Ids are required for the update-by-id operation")
 	}
-	//validate/update script content based on valid field specifications
-	if fieldCount > 0 && fieldCount == fieldLen {
-		validUpdateItemCount += 1
-		updateQuery = itemScript
-	} else {
-		invalidUpdateItemCount += 1
+	tableFields = computeUpdateFields(actionParams, tableFields)
+	// only one actionParams record is required for update by docIds
+	setScript, fieldValues, err := computeSetScript(actionParams[0], tableFields)
+	if err != nil {
+		return types.UpdateQueryResponseType{}, err
 	}
+	// from / where condition (where-in-values)
+	for _, id := range recordIds {
+		fieldValues = append(fieldValues, id)
+	}
+	whereQuery := fmt.Sprintf("WHERE id IN(%v)", ComputePlaceholders(len(fieldValues)-len(recordIds)+1, len(recordIds)))
+	return types.UpdateQueryResponseType{
+		UpdateQuery: fmt.Sprintf("UPDATE %v SET%v %v", tableName, setScript, whereQuery),
+		WhereQuery:  whereQuery,
+		FieldValues: fieldValues,
+	}, nil
+}
 
-	// check is there was invalid update items
-	if invalidUpdateItemCount > 0 {
-		return "", errors.New(fmt.Sprintf("Invalid action-params [%v]", invalidUpdateItemCount))
+// ComputeUpdateQueryByParam function computes the update-script for the records that met the where-params
+func ComputeUpdateQueryByParam(tableName string, actionParams types.ActionParamsType, where types.QueryParamType, tableFields []string) (types.UpdateQueryResponseType, error) {
+	if tableName == "" || len(actionParams) < 1 || len(where) < 1 {
+		return types.UpdateQueryResponseType{}, errors.New("table-name, action-params and where-params are required for the update-by-params operation")
 	}
-
-	if whereScript, err := ComputeWhereQuery(where); err == nil {
-		updateQuery += " " + whereScript
-		return updateQuery, nil
+	tableFields = computeUpdateFields(actionParams, tableFields)
+	// only one actionParams record is required for update by where-params
+	setScript, fieldValues, err := computeSetScript(actionParams[0], tableFields)
+	if err != nil {
+		return types.UpdateQueryResponseType{}, err
+	}
+	// where-placeholders follow the set-placeholders
+	if whereRes, err := ComputeWhereQuery(where, len(fieldValues)); err == nil {
+		return types.UpdateQueryResponseType{
+			UpdateQuery: fmt.Sprintf("UPDATE %v SET%v %v", tableName, setScript, whereRes.WhereQuery),
+			WhereQuery:  whereRes.WhereQuery,
+			FieldValues: append(fieldValues, whereRes.FieldValues...),
+		}, nil
 	} else {
-		return "", errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
+		return types.UpdateQueryResponseType{}, errors.New(fmt.Sprintf("error computing where-query condition(s): %v", err.Error()))
 	}
 }
@@ -0,0 +1,69 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute update-SQL scripts test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestComputeUpdateQuery(t *testing.T) {
+	actionParams := types.ActionParamsType{{"name": "abi", "age": 30}}
+	tableFields := []string{"name", "age"}
+	testCases := []struct {
+		name        string
+		recordIds   []string
+		where       types.QueryParamType
+		updateQuery string
+		fieldValues []interface{}
+	}{
+		{
+			name:        "should compute the update-by-id query, with the id placeholders after the set placeholders:",
+			recordIds:   []string{"id-1", "id-2"},
+			updateQuery: "UPDATE users SET name=$1, age=$2 WHERE id IN($3, $4)",
+			fieldValues: []interface{}{"abi", 30, "id-1", "id-2"},
+		},
+		{
+			name: "should compute the update-by-param query, with the where placeholders after the set placeholders:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"in": []string{"ab", "abbey"}}}},
+				}},
+			},
+			updateQuery: "UPDATE users SET name=$1, age=$2 WHERE (name IN ($3, $4))",
+			fieldValues: []interface{}{"abi", 30, "ab", "abbey"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				var res types.UpdateQueryResponseType
+				var err error
+				if len(tc.recordIds) > 0 {
+					res, err = ComputeUpdateQueryById("users", actionParams, tc.recordIds, tableFields)
+				} else {
+					res, err = ComputeUpdateQueryByParam("users", actionParams, tc.where, tableFields)
+				}
+				mctest.AssertEquals(t, err, nil, "update-query error should be nil")
+				mctest.AssertEquals(t, res.UpdateQuery, tc.updateQuery, "update-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "update-query values should be as expected")
+			},
+		})
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the update-query where-script with the not-deleted condition:",
+		TestFunc: func() {
+			updateQuery, err := ComputeUpdateQuery("users", types.ActionParamsType{{"id": "id-1", "name": "abi", "version": 2}}, []string{"name"}, "version")
+			mctest.AssertEquals(t, err, nil, "update-query error should be nil")
+			res := ComputeNotDeletedUpdateQuery(updateQuery[0])
+			mctest.AssertEquals(t, res.UpdateQuery, "UPDATE users SET name=$1, version=version + 1 WHERE deleted_at IS NULL AND (id=$2 AND version=$3)", "not-deleted update-query should be as expected")
+			mctest.AssertStrictEquals(t, res.FieldValues, []interface{}{"abi", "id-1", 2}, "not-deleted update-query values should be as expected")
+		},
+	})
+	mctest.PostTestResult()
+}
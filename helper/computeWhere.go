@@ -5,6 +5,7 @@
 package helper
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/abbeymart/mccrud/types"
@@ -15,916 +16,505 @@ import (
 	"time"
 )
 
-// ComputeWhereQuery function computes the multi-cases where-conditions for crud-operations
-func ComputeWhereQuery(where types.QueryParamType) (string, error) {
+// maxWhereDepth is the maximum nesting depth of the where-groups, i.e. the group and nested groups (QueryItemType.Group)
+const maxWhereDepth = 10
+
+// ComputeWhereQuery function computes the multi-cases where-conditions for crud-operations.
+// The groups (by groupOrder) and group-items (by groupItemOrder) are linked by the groupLinkOp/groupItemOp
+// (AND, OR), negated by the groupNot/groupItemNot, and the group-items may be nested groups (sub-expressions).
+// The where-values are returned as FieldValues, in placeholder order, and never pasted into the SQL script.
+// fieldLength is the count of placeholders already in use by the calling query, i.e. the where-placeholders
+// start from $fieldLength+1
+func ComputeWhereQuery(where types.QueryParamType, fieldLength int) (types.WhereQueryResponseType, error) {
+	return computeWhereQuery(where, fieldLength, nil)
+}
+
+// computeWhereQuery function computes the where-conditions, as ComputeWhereQuery. The fieldScripts maps the trusted
+// field-names, e.g. the having aggregate aliases, to the SQL scripts computed by the caller (e.g. COUNT(*)), in place
+// of the field-names; the other field-names must be valid (unquoted) identifiers.
+func computeWhereQuery(where types.QueryParamType, fieldLength int, fieldScripts map[string]string) (types.WhereQueryResponseType, error) {
 	if len(where) < 1 {
-		return "", errors.New("where condition is required")
+		return types.WhereQueryResponseType{}, errors.New("where condition is required")
 	}
-	// groups length/size
-	groupsLen := len(where)
-	// variable for empty group (i.e. contains no fieldItems)
-	emptyGroupCount := 0
-	// variable for valid group count, i.e. group with groupItems
-	groupCount := 0
-	// sort where by groupOrder (ASC)
-	sort.SliceStable(where, func(i, j int) bool {
-		return where[i].GroupOrder < where[j].GroupOrder
+	// sort (a copy of) where by groupOrder (ASC)
+	groups := append(types.QueryParamType{}, where...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].GroupOrder < groups[j].GroupOrder
 	})
-	// compute where script from where
-	// iterate through where (groups)
-	whereQuery := "WHERE "
-	for _, group := range where {
-		var (
-			emptyGroupItemCount = 0 // variable to determine empty/unspecified fieldName/Op/Value
-			groupItemCount      = 0 // valid gItem count, i.e. group item with valid name and value
-		)
-		groupItemsLen := len(group.GroupItems) // total items in a group
-		// check groupItems length, if 0 continue to the next group
-		if groupItemsLen < 1 {
-			emptyGroupCount += 1
+	// where-values, in placeholder order
+	var fieldValues []interface{}
+	// compute where script from the groups' scripts, linked by the groupLinkOp
+	var groupScripts []string
+	var groupLinkOps []string
+	for _, group := range groups {
+		groupLinkOp, err := computeLinkOp(group.GroupLinkOp)
+		if err != nil {
+			return types.WhereQueryResponseType{}, err
+		}
+		groupScript, groupValues, err := computeWhereGroup(group, 1, fieldLength+len(fieldValues)+1, fieldScripts)
+		if err != nil {
+			return types.WhereQueryResponseType{}, err
+		}
+		// skip the empty group, i.e. contains no valid group-items
+		if groupScript == "" {
 			continue
 		}
-		// count valid group, i.e. group with groupItems
-		groupCount += 1
-		// sort group items by gItem/fieldOrder (ASC)
-		gItems := group.GroupItems
-		sort.SliceStable(gItems, func(i, j int) bool {
-			return gItems[i].GroupItemOrder < gItems[j].GroupItemOrder
-		})
-		// compute the group-items query/script
-		gItemQuery := "("
-		for _, gItem := range gItems {
-			// check gItem's fieldName, fieldOperator and fieldValue
-			fieldName := ""
-			fieldOperator := ""
-			var fieldValue interface{}
+		groupScripts = append(groupScripts, groupScript)
+		groupLinkOps = append(groupLinkOps, groupLinkOp)
+		fieldValues = append(fieldValues, groupValues...)
+	}
+	// check WHERE script contains at least one condition, otherwise raise an exception
+	if len(groupScripts) < 1 {
+		return types.WhereQueryResponseType{}, errors.New("no valid where condition specified")
+	}
 
-			// ensure that len(gItem.GroupItem) == 1
-			if len(gItem.GroupItem) != 1 {
-				return "", errors.New(fmt.Sprintf("Only 1 field-name criteria is expected for each group-item"))
-			}
+	// if all went well, return valid where script and the where-values
+	return types.WhereQueryResponseType{
+		WhereQuery:  "WHERE " + joinConditions(groupScripts, groupLinkOps),
+		FieldValues: fieldValues,
+	}, nil
+}
 
-			for fName, opVal := range gItem.GroupItem {
-				fieldName = fName
-				// ensure that len(opVal) == 1
-				if len(opVal) != 1 {
-					return "", errors.New(fmt.Sprintf("Only 1 operator-value criteria is expected for a field-name: %v", fieldName))
-				}
-				for fOp, val := range opVal {
-					fieldOperator = fOp
-					fieldValue = val
-				}
+// computeLinkOp function validates and returns the group/group-item link-operator (AND, OR), default: AND
+func computeLinkOp(linkOp string) (string, error) {
+	switch strings.ToLower(linkOp) {
+	case "":
+		return strings.ToUpper(groupOperators.AND), nil
+	case strings.ToLower(groupOperators.AND), strings.ToLower(groupOperators.OR):
+		return strings.ToUpper(linkOp), nil
+	default:
+		return "", errors.New(fmt.Sprintf("invalid group/group-item link-operator [%v], and/or is expected", linkOp))
+	}
+}
+
+// joinConditions function joins the conditions, each linked to the next condition by its linkOp
+func joinConditions(conditions []string, linkOps []string) string {
+	script := conditions[0]
+	for i := 1; i < len(conditions); i++ {
+		script += " " + linkOps[i-1] + " " + conditions[i]
+	}
+	return script
+}
+
+// computeWhereGroup function computes the group-items script, in brackets and negated for the groupNot, with the
+// value-placeholder(s) starting from $placeholderIndex. The nested groups are computed recursively, up to the
+// maxWhereDepth. The empty script is returned, if the group contains no valid group-items.
+func computeWhereGroup(group types.QueryGroupType, depth int, placeholderIndex int, fieldScripts map[string]string) (string, []interface{}, error) {
+	if depth > maxWhereDepth {
+		return "", nil, errors.New(fmt.Sprintf("where-groups exceeded the maximum nesting depth [%v]", maxWhereDepth))
+	}
+	// sort (a copy of) the group items by gItem/fieldOrder (ASC)
+	gItems := append([]types.QueryItemType{}, group.GroupItems...)
+	sort.SliceStable(gItems, func(i, j int) bool {
+		return gItems[i].GroupItemOrder < gItems[j].GroupItemOrder
+	})
+	var fieldValues []interface{}
+	var itemScripts []string
+	var itemLinkOps []string
+	for _, gItem := range gItems {
+		gItemLinkOp, err := computeLinkOp(gItem.GroupItemOp)
+		if err != nil {
+			return "", nil, err
+		}
+		var itemScript string
+		var itemValues []interface{}
+		if gItem.Group != nil {
+			// nested group
+			if len(gItem.GroupItem) > 0 {
+				return "", nil, errors.New("either the field-name criteria (groupItem) or the nested group is expected for each group-item")
 			}
-			if fieldName == "" || fieldOperator == "" || fieldValue == nil {
-				// skip missing field/continue to the next gItem, or return error?
-				emptyGroupItemCount += 1
-				continue
-				//return "", errors.New("field-name, operator and/or value are required")
+			itemScript, itemValues, err = computeWhereGroup(*gItem.Group, depth+1, placeholderIndex+len(fieldValues), fieldScripts)
+			if err != nil {
+				return "", nil, err
 			}
-			// count valid gItem
-			groupItemCount += 1
-			switch strings.ToLower(fieldOperator) {
-			case strings.ToLower(operators.Equals):
-				switch fieldValue.(type) {
-				case time.Time:
-					if fVal, ok := fieldValue.(time.Time); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue := "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, currentFieldValue)
-					}
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name:%v | field_value:%v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, "'"+fVal+"'")
-					}
-				case bool:
-					if fVal, ok := fieldValue.(bool); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case int8:
-					if fVal, ok := fieldValue.(int8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case int16:
-					if fVal, ok := fieldValue.(int16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case int32:
-					if fVal, ok := fieldValue.(int32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case int64:
-					if fVal, ok := fieldValue.(int64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case int:
-					if fVal, ok := fieldValue.(int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case uint8:
-					if fVal, ok := fieldValue.(uint8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case uint16:
-					if fVal, ok := fieldValue.(uint16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case uint32:
-					if fVal, ok := fieldValue.(uint32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case uint64:
-					if fVal, ok := fieldValue.(uint64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case uint:
-					if fVal, ok := fieldValue.(uint); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case float32:
-					if fVal, ok := fieldValue.(float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case float64:
-					if fVal, ok := fieldValue.(float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case []string:
-					if fVal, ok := fieldValue.([]string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case []int:
-					if fVal, ok := fieldValue.([]int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case []float64:
-					if fVal, ok := fieldValue.([]float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				case []struct{}:
-					if fVal, ok := fieldValue.([]struct{}); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v=%v", fieldName, fVal)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.NotEquals):
-				switch fieldValue.(type) {
-				case time.Time:
-					if fVal, ok := fieldValue.(time.Time); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue := "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, currentFieldValue)
-					}
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, "'"+fVal+"'")
-					}
-				case bool:
-					if fVal, ok := fieldValue.(bool); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case int8:
-					if fVal, ok := fieldValue.(int8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case int16:
-					if fVal, ok := fieldValue.(int16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case int32:
-					if fVal, ok := fieldValue.(int32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case int64:
-					if fVal, ok := fieldValue.(int64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case int:
-					if fVal, ok := fieldValue.(int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case uint8:
-					if fVal, ok := fieldValue.(uint8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case uint16:
-					if fVal, ok := fieldValue.(uint16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case uint32:
-					if fVal, ok := fieldValue.(uint32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case uint64:
-					if fVal, ok := fieldValue.(uint64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case uint:
-					if fVal, ok := fieldValue.(uint); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case float32:
-					if fVal, ok := fieldValue.(float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case float64:
-					if fVal, ok := fieldValue.(float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case []string:
-					if fVal, ok := fieldValue.([]string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case []int:
-					if fVal, ok := fieldValue.([]int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				case []struct{}:
-					if fVal, ok := fieldValue.([]struct{}); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<>%v", fieldName, fVal)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.LessThan):
-				switch fieldValue.(type) {
-				case time.Time:
-					if fVal, ok := fieldValue.(time.Time); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue := "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, currentFieldValue)
-					}
-				case int8:
-					if fVal, ok := fieldValue.(int8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case int16:
-					if fVal, ok := fieldValue.(int16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case int32:
-					if fVal, ok := fieldValue.(int32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case int64:
-					if fVal, ok := fieldValue.(int64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case int:
-					if fVal, ok := fieldValue.(int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case uint8:
-					if fVal, ok := fieldValue.(uint8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case uint16:
-					if fVal, ok := fieldValue.(uint16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case uint32:
-					if fVal, ok := fieldValue.(uint32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case uint64:
-					if fVal, ok := fieldValue.(uint64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case uint:
-					if fVal, ok := fieldValue.(uint); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case float32:
-					if fVal, ok := fieldValue.(float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				case float64:
-					if fVal, ok := fieldValue.(float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<%v", fieldName, fVal)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value: %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.LessThanOrEquals):
-				switch fieldValue.(type) {
-				case time.Time:
-					if fVal, ok := fieldValue.(time.Time); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue := "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, currentFieldValue)
-					}
-				case int8:
-					if fVal, ok := fieldValue.(int8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case int16:
-					if fVal, ok := fieldValue.(int16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case int32:
-					if fVal, ok := fieldValue.(int32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case int64:
-					if fVal, ok := fieldValue.(int64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case int:
-					if fVal, ok := fieldValue.(int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case uint8:
-					if fVal, ok := fieldValue.(uint8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case uint16:
-					if fVal, ok := fieldValue.(uint16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case uint32:
-					if fVal, ok := fieldValue.(uint32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case uint64:
-					if fVal, ok := fieldValue.(uint64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case uint:
-					if fVal, ok := fieldValue.(uint); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case float32:
-					if fVal, ok := fieldValue.(float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				case float64:
-					if fVal, ok := fieldValue.(float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v<=%v", fieldName, fVal)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field[%v], type for field-value %v", fieldName, fieldValue))
-				}
-				groupItemOps := []string{"and", "or"}
-				groupItemOp := gItem.GroupItemOp
-				if groupItemOp == "" || !ArrayStringContains(groupItemOps, strings.ToLower(groupItemOp)) {
-					groupItemOp = groupOperators.AND // use GroupOpTypes.AND as default operator
-				}
-				if groupItemsLen > 1 && groupItemCount < (groupItemsLen-emptyGroupItemCount) {
-					gItemQuery = gItemQuery + " " + strings.ToUpper(gItem.GroupItemOp) + " "
-				}
-			case strings.ToLower(operators.GreaterThan):
-				switch fieldValue.(type) {
-				case time.Time:
-					if fVal, ok := fieldValue.(time.Time); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue := "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, currentFieldValue)
-					}
-				case int8:
-					if fVal, ok := fieldValue.(int8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case int16:
-					if fVal, ok := fieldValue.(int16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case int32:
-					if fVal, ok := fieldValue.(int32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case int64:
-					if fVal, ok := fieldValue.(int64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case int:
-					if fVal, ok := fieldValue.(int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case uint8:
-					if fVal, ok := fieldValue.(uint8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case uint16:
-					if fVal, ok := fieldValue.(uint16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case uint32:
-					if fVal, ok := fieldValue.(uint32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case uint64:
-					if fVal, ok := fieldValue.(uint64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case uint:
-					if fVal, ok := fieldValue.(uint); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case float32:
-					if fVal, ok := fieldValue.(float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				case float64:
-					if fVal, ok := fieldValue.(float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>%v", fieldName, fVal)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.GreaterThanOrEquals):
-				switch fieldValue.(type) {
-				case time.Time:
-					if fVal, ok := fieldValue.(time.Time); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						currentFieldValue := "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, currentFieldValue)
-					}
-				case int8:
-					if fVal, ok := fieldValue.(int8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case int16:
-					if fVal, ok := fieldValue.(int16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case int32:
-					if fVal, ok := fieldValue.(int32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case int64:
-					if fVal, ok := fieldValue.(int64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case int:
-					if fVal, ok := fieldValue.(int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case uint8:
-					if fVal, ok := fieldValue.(uint8); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case uint16:
-					if fVal, ok := fieldValue.(uint16); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case uint32:
-					if fVal, ok := fieldValue.(uint32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case uint64:
-					if fVal, ok := fieldValue.(uint64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case uint:
-					if fVal, ok := fieldValue.(uint); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case float32:
-					if fVal, ok := fieldValue.(float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				case float64:
-					if fVal, ok := fieldValue.(float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fmt.Sprintf("%v>=%v", fieldName, fVal)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.In):
-				switch fieldValue.(type) {
-				case []string:
-					if fVal, ok := fieldValue.([]string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := ""
-						idLen := len(fVal)
-						for idCount, id := range fVal {
-							inValues += "'" + id + "'"
-							if idLen > 1 && idCount < idLen-1 {
-								inValues += ", "
-							}
-						}
-						gItemQuery += fmt.Sprintf("%v IN (%v)", fieldName, inValues)
-					}
-				case []bool:
-					if fVal, ok := fieldValue.([]bool); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v IN %v", fieldName, inValues)
-					}
-				case []int:
-					if fVal, ok := fieldValue.([]int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v IN %v", fieldName, inValues)
-					}
-				case []float32:
-					if fVal, ok := fieldValue.([]float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v IN %v", fieldName, inValues)
-					}
-				case []float64:
-					if fVal, ok := fieldValue.([]float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v IN %v", fieldName, inValues)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.NotIn):
-				switch fieldValue.(type) {
-				case []string:
-					if fVal, ok := fieldValue.([]string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := ""
-						idLen := len(fVal)
-						for idCount, id := range fVal {
-							inValues += "'" + id + "'"
-							if idLen > 1 && idCount < idLen-1 {
-								inValues += ", "
-							}
-						}
-						gItemQuery += fmt.Sprintf("%v NOT IN (%v)", fieldName, inValues)
-					}
-				case []bool:
-					if fVal, ok := fieldValue.([]bool); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v NOT IN %v", fieldName, inValues)
-					}
-				case []int:
-					if fVal, ok := fieldValue.([]int); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v NOT IN %v", fieldName, inValues)
-					}
-				case []float32:
-					if fVal, ok := fieldValue.([]float32); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v NOT IN %v", fieldName, inValues)
-					}
-				case []float64:
-					if fVal, ok := fieldValue.([]float64); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						inValues := "("
-						fValLen := len(fVal)
-						for i, v := range fVal {
-							inValues += fmt.Sprintf("%v", v)
-							if fValLen > 1 && i < fValLen-1 {
-								inValues += inValues + ", "
-							}
-						}
-						inValues += ")"
-						gItemQuery += fmt.Sprintf("%v NOT IN %v", fieldName, inValues)
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.StartsWith):
-				switch fieldValue.(type) {
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						//gItemQuery += fmt.Sprintf("%v LIKE %%v", fieldName, fVal)
-						gItemQuery += fieldName + " LIKE " + fVal + "%"
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.EndsWith):
-				switch fieldValue.(type) {
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fieldName + " LIKE %" + fVal
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.NotStartsWith):
-				switch fieldValue.(type) {
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fieldName + " NOT LIKE " + fVal + "%"
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.NotEndsWith):
-				switch fieldValue.(type) {
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fieldName + " NOT LIKE %" + fVal
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.Includes):
-				switch fieldValue.(type) {
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fieldName + " LIKE %" + fVal + "%"
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
-				}
-			case strings.ToLower(operators.NotIncludes):
-				switch fieldValue.(type) {
-				case string:
-					if fVal, ok := fieldValue.(string); !ok {
-						return "", errors.New(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
-					} else {
-						gItemQuery += fieldName + " NOT LIKE %" + fVal + "%"
-					}
-				default:
-					return "", errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		} else {
+			itemScript, itemValues, err = computeGroupItem(gItem, placeholderIndex+len(fieldValues), fieldScripts)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		// skip missing field/nested group, i.e. empty group-item
+		if itemScript == "" {
+			continue
+		}
+		if gItem.GroupItemNot {
+			if gItem.Group == nil {
+				itemScript = "(" + itemScript + ")"
+			}
+			itemScript = "NOT " + itemScript
+		}
+		itemScripts = append(itemScripts, itemScript)
+		itemLinkOps = append(itemLinkOps, gItemLinkOp)
+		fieldValues = append(fieldValues, itemValues...)
+	}
+	if len(itemScripts) < 1 {
+		return "", nil, nil
+	}
+	groupScript := "(" + joinConditions(itemScripts, itemLinkOps) + ")"
+	if group.GroupNot {
+		groupScript = "NOT " + groupScript
+	}
+	return groupScript, fieldValues, nil
+}
+
+// computeGroupItem function computes the field-condition script of the group-item (single field-name criteria),
+// or the empty script, for the missing field-name, operator or value
+func computeGroupItem(gItem types.QueryItemType, placeholderIndex int, fieldScripts map[string]string) (string, []interface{}, error) {
+	// check gItem's fieldName, fieldOperator and fieldValue
+	fieldName := ""
+	fieldOperator := ""
+	var fieldValue interface{}
+
+	// ensure that len(gItem.GroupItem) == 1
+	if len(gItem.GroupItem) != 1 {
+		return "", nil, errors.New(fmt.Sprintf("Only 1 field-name criteria is expected for each group-item"))
+	}
+
+	for fName, opVal := range gItem.GroupItem {
+		fieldName = fName
+		// ensure that len(opVal) == 1
+		if len(opVal) != 1 {
+			return "", nil, errors.New(fmt.Sprintf("Only 1 operator-value criteria is expected for a field-name: %v", fieldName))
+		}
+		for fOp, val := range opVal {
+			fieldOperator = fOp
+			fieldValue = val
+		}
+	}
+	if fieldName == "" || fieldOperator == "" || fieldValue == nil {
+		// skip missing field/continue to the next gItem
+		return "", nil, nil
+	}
+	// the trusted field-name script, computed by the caller, e.g. the having aggregate script
+	if fieldScript, ok := fieldScripts[fieldName]; ok {
+		return computeFieldCondition(fieldScript, fieldOperator, fieldValue, placeholderIndex)
+	}
+	// compute the field-condition, with value-placeholder(s) starting from the placeholderIndex
+	return computeWhereItem(fieldName, fieldOperator, fieldValue, placeholderIndex)
+}
+
+// computeWhereItem function computes the field-condition script for the fieldOperator, with value-placeholder(s)
+// starting from $placeholderIndex, and returns the script and the value(s) for the placeholder(s)
+func computeWhereItem(fieldName string, fieldOperator string, fieldValue interface{}, placeholderIndex int) (string, []interface{}, error) {
+	// the field-name is pasted into the SQL script, i.e. it must be a valid (unquoted) identifier; the search
+	// field-names (comma-separated) are validated by the search-vector
+	if strings.ToLower(fieldOperator) != types.Search && !IsValidFieldName(fieldName) {
+		return "", nil, errors.New(fmt.Sprintf("invalid where field_name[%v]", fieldName))
+	}
+	return computeFieldCondition(fieldName, fieldOperator, fieldValue, placeholderIndex)
+}
+
+// computeFieldCondition function computes the field-condition script for the (validated) fieldName or field-script
+// and the fieldOperator, with value-placeholder(s) starting from $placeholderIndex
+func computeFieldCondition(fieldName string, fieldOperator string, fieldValue interface{}, placeholderIndex int) (string, []interface{}, error) {
+	// literal-string pattern operators, e.g. startsWith: the value wildcards (%, _) are escaped
+	if likeOp, ok := likeOperators[strings.ToLower(fieldOperator)]; ok && likeOp.literal {
+		fVal, ok := fieldValue.(string)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v %v $%v", fieldName, likeOp.sqlOperator, placeholderIndex), []interface{}{likeOp.prefix + escapeLikeValue(fVal) + likeOp.suffix}, nil
+	}
+	switch strings.ToLower(fieldOperator) {
+	case strings.ToLower(operators.Equals):
+		if !isEqualityValue(fieldValue) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v=$%v", fieldName, placeholderIndex), []interface{}{fieldValue}, nil
+	case strings.ToLower(operators.NotEquals):
+		if !isEqualityValue(fieldValue) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v<>$%v", fieldName, placeholderIndex), []interface{}{fieldValue}, nil
+	case strings.ToLower(operators.LessThan):
+		if !isComparableValue(fieldValue) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value: %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v<$%v", fieldName, placeholderIndex), []interface{}{fieldValue}, nil
+	case strings.ToLower(operators.LessThanOrEquals):
+		if !isComparableValue(fieldValue) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field[%v], type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v<=$%v", fieldName, placeholderIndex), []interface{}{fieldValue}, nil
+	case strings.ToLower(operators.GreaterThan):
+		if !isComparableValue(fieldValue) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v>$%v", fieldName, placeholderIndex), []interface{}{fieldValue}, nil
+	case strings.ToLower(operators.GreaterThanOrEquals):
+		if !isComparableValue(fieldValue) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v>=$%v", fieldName, placeholderIndex), []interface{}{fieldValue}, nil
+	case strings.ToLower(operators.In):
+		inValues, ok := inFieldValues(fieldValue)
+		if !ok || len(inValues) < 1 {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v IN (%v)", fieldName, ComputePlaceholders(placeholderIndex, len(inValues))), inValues, nil
+	case strings.ToLower(operators.NotIn):
+		inValues, ok := inFieldValues(fieldValue)
+		if !ok || len(inValues) < 1 {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v NOT IN (%v)", fieldName, ComputePlaceholders(placeholderIndex, len(inValues))), inValues, nil
+	case types.Like, types.NotLike, types.ILike, types.NotILike:
+		// caller pattern, with the % and _ wildcards
+		fVal, ok := fieldValue.(string)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v %v $%v", fieldName, likeOperators[strings.ToLower(fieldOperator)].sqlOperator, placeholderIndex), []interface{}{fVal}, nil
+	case types.Between, types.NotBetween:
+		rangeValues, ok := inFieldValues(fieldValue)
+		if !ok || len(rangeValues) != 2 || !isRangeValue(rangeValues[0]) || !isRangeValue(rangeValues[1]) {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v, [low, high] values are expected", fieldName, fieldValue))
+		}
+		sqlOperator := "BETWEEN"
+		if strings.ToLower(fieldOperator) == types.NotBetween {
+			sqlOperator = "NOT BETWEEN"
+		}
+		return fmt.Sprintf("%v %v $%v AND $%v", fieldName, sqlOperator, placeholderIndex, placeholderIndex+1), rangeValues, nil
+	case types.IsNull, types.IsNotNull:
+		isNull, ok := fieldValue.(bool)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v, true or false is expected", fieldName, fieldValue))
+		}
+		if isNull == (strings.ToLower(fieldOperator) == types.IsNull) {
+			return fmt.Sprintf("%v IS NULL", fieldName), nil, nil
+		}
+		return fmt.Sprintf("%v IS NOT NULL", fieldName), nil, nil
+	case types.Regex, types.IRegex, types.NotRegex, types.NotIRegex:
+		fVal, ok := fieldValue.(string)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v %v $%v", fieldName, regexOperators[strings.ToLower(fieldOperator)], placeholderIndex), []interface{}{fVal}, nil
+	case types.ArrayContains, types.ArrayContainedBy, types.ArrayOverlaps:
+		arrayValue, ok := arrayFieldValue(fieldValue)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v, an array of values of the same type is expected", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v %v $%v", fieldName, arrayOperators[strings.ToLower(fieldOperator)], placeholderIndex), []interface{}{arrayValue}, nil
+	case types.JsonContains, types.JsonContainedBy:
+		jsonValue, ok := jsonFieldValue(fieldValue)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v, a json document is expected", fieldName, fieldValue))
+		}
+		sqlOperator := "@>"
+		if strings.ToLower(fieldOperator) == types.JsonContainedBy {
+			sqlOperator = "<@"
+		}
+		return fmt.Sprintf("%v %v $%v::jsonb", fieldName, sqlOperator, placeholderIndex), []interface{}{jsonValue}, nil
+	case types.JsonHasKey:
+		fVal, ok := fieldValue.(string)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		return fmt.Sprintf("%v ? $%v", fieldName, placeholderIndex), []interface{}{fVal}, nil
+	case types.JsonHasAnyKeys, types.JsonHasAllKeys:
+		keys, ok := arrayFieldValue(fieldValue)
+		if _, isStrings := keys.([]string); !ok || !isStrings {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v, an array of keys is expected", fieldName, fieldValue))
+		}
+		sqlOperator := "?|"
+		if strings.ToLower(fieldOperator) == types.JsonHasAllKeys {
+			sqlOperator = "?&"
+		}
+		return fmt.Sprintf("%v %v $%v::text[]", fieldName, sqlOperator, placeholderIndex), []interface{}{keys}, nil
+	case types.Search:
+		return computeSearchItem(fieldName, fieldValue, placeholderIndex)
+	case types.JsonPath, types.JsonPathExists:
+		fVal, ok := fieldValue.(string)
+		if !ok {
+			return "", nil, errors.New(fmt.Sprintf("Unsupported field-name[%v] type for field-value %v", fieldName, fieldValue))
+		}
+		sqlOperator := "@@"
+		if strings.ToLower(fieldOperator) == types.JsonPathExists {
+			sqlOperator = "@?"
+		}
+		return fmt.Sprintf("%v %v $%v::jsonpath", fieldName, sqlOperator, placeholderIndex), []interface{}{fVal}, nil
+	default:
+		return "", nil, errors.New(fmt.Sprintf("Unknown or unsupported field(%v) operator: %v", fieldName, fieldOperator))
+	}
+}
+
+// likeOperatorType is the (NOT) LIKE/ILIKE operator and, for the literal-string operators, the wildcard prefix/suffix
+type likeOperatorType struct {
+	sqlOperator string
+	literal     bool
+	prefix      string
+	suffix      string
+}
+
+// likeOperators maps the pattern operators to the SQL (NOT) LIKE/ILIKE operators
+var likeOperators = map[string]likeOperatorType{
+	operators.StartsWith:    {sqlOperator: "LIKE", literal: true, suffix: "%"},
+	operators.EndsWith:      {sqlOperator: "LIKE", literal: true, prefix: "%"},
+	operators.Includes:      {sqlOperator: "LIKE", literal: true, prefix: "%", suffix: "%"},
+	operators.NotStartsWith: {sqlOperator: "NOT LIKE", literal: true, suffix: "%"},
+	operators.NotEndsWith:   {sqlOperator: "NOT LIKE", literal: true, prefix: "%"},
+	operators.NotIncludes:   {sqlOperator: "NOT LIKE", literal: true, prefix: "%", suffix: "%"},
+	types.IStartsWith:       {sqlOperator: "ILIKE", literal: true, suffix: "%"},
+	types.IEndsWith:         {sqlOperator: "ILIKE", literal: true, prefix: "%"},
+	types.IIncludes:         {sqlOperator: "ILIKE", literal: true, prefix: "%", suffix: "%"},
+	types.NotIStartsWith:    {sqlOperator: "NOT ILIKE", literal: true, suffix: "%"},
+	types.NotIEndsWith:      {sqlOperator: "NOT ILIKE", literal: true, prefix: "%"},
+	types.NotIIncludes:      {sqlOperator: "NOT ILIKE", literal: true, prefix: "%", suffix: "%"},
+	types.Like:              {sqlOperator: "LIKE"},
+	types.NotLike:           {sqlOperator: "NOT LIKE"},
+	types.ILike:             {sqlOperator: "ILIKE"},
+	types.NotILike:          {sqlOperator: "NOT ILIKE"},
+}
+
+// regexOperators maps the regex operators to the SQL POSIX regular-expression operators
+var regexOperators = map[string]string{
+	types.Regex:     "~",
+	types.IRegex:    "~*",
+	types.NotRegex:  "!~",
+	types.NotIRegex: "!~*",
+}
+
+// arrayOperators maps the array operators to the SQL array operators
+var arrayOperators = map[string]string{
+	types.ArrayContains:    "@>",
+	types.ArrayContainedBy: "<@",
+	types.ArrayOverlaps:    "&&",
+}
+
+// escapeLikeValue function escapes the LIKE wildcards (% and _) and the escape character (\), for the literal value
+func escapeLikeValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// isRangeValue function determines if the fieldValue is valid for the between and notbetween operators,
+// i.e. comparable or string (e.g. date/time) values
+func isRangeValue(fieldValue interface{}) bool {
+	if _, ok := fieldValue.(string); ok {
+		return true
+	}
+	return isComparableValue(fieldValue)
+}
+
+// arrayFieldValue function returns the array-operators fieldValue as a typed slice, e.g. []string, for the
+// (single) array placeholder. The []interface{} (e.g. json-decoded) values must be of the same type.
+func arrayFieldValue(fieldValue interface{}) (interface{}, bool) {
+	switch fVal := fieldValue.(type) {
+	case []string, []bool, []int, []int64, []float64, []time.Time:
+		return fVal, true
+	case []interface{}:
+		if len(fVal) < 1 {
+			return nil, false
+		}
+		switch fVal[0].(type) {
+		case string:
+			var values []string
+			for _, v := range fVal {
+				value, ok := v.(string)
+				if !ok {
+					return nil, false
 				}
-			default:
-				return "", errors.New(fmt.Sprintf("Unknown or unsupported field(%v) operator: %v", fieldName, fieldOperator))
+				values = append(values, value)
 			}
-			//validate acceptable groupItemLinkOperators (and || or)
-			gItemLinkOp := gItem.GroupItemOp
-			gItemLinkOps := []string{"and", "or"}
-			if gItemLinkOp == "" || !ArrayStringContains(gItemLinkOps, strings.ToLower(gItemLinkOp)) {
-				gItemLinkOp = groupOperators.AND // default operator
+			return values, true
+		case bool:
+			var values []bool
+			for _, v := range fVal {
+				value, ok := v.(bool)
+				if !ok {
+					return nil, false
+				}
+				values = append(values, value)
 			}
-			// add gItemLinkOp, if groupItemsLen > 1 && groupItemCount < (groupItemsLen-emptyGroupItemCount)
-			if groupItemsLen > 1 && groupItemCount < (groupItemsLen-emptyGroupItemCount) {
-				gItemQuery += " " + strings.ToUpper(gItemLinkOp) + " "
+			return values, true
+		case float64:
+			var values []float64
+			for _, v := range fVal {
+				value, ok := v.(float64)
+				if !ok {
+					return nil, false
+				}
+				values = append(values, value)
 			}
+			return values, true
 		}
-		// continue to the next group iteration, if fieldItems is empty for the current group
-		if emptyGroupItemCount == groupItemsLen {
-			continue
+	}
+	return nil, false
+}
+
+// jsonFieldValue function returns the json-operators fieldValue as a json document (string), i.e. the json string
+// or the json-encoded value (e.g. map or slice)
+func jsonFieldValue(fieldValue interface{}) (string, bool) {
+	if fVal, ok := fieldValue.(string); ok {
+		return fVal, json.Valid([]byte(fVal))
+	}
+	jsonValue, err := json.Marshal(fieldValue)
+	if err != nil {
+		return "", false
+	}
+	return string(jsonValue), true
+}
+
+// isComparableValue function determines if the fieldValue is valid for the lt, lte, gt and gte operators
+func isComparableValue(fieldValue interface{}) bool {
+	switch fieldValue.(type) {
+	case time.Time, int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEqualityValue function determines if the fieldValue is valid for the eq and neq operators
+func isEqualityValue(fieldValue interface{}) bool {
+	if isComparableValue(fieldValue) {
+		return true
+	}
+	switch fieldValue.(type) {
+	case string, bool, []string, []int, []float64, []struct{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// inFieldValues function returns the in/notin fieldValue as a slice of values, one value per placeholder
+func inFieldValues(fieldValue interface{}) ([]interface{}, bool) {
+	var inValues []interface{}
+	switch fVal := fieldValue.(type) {
+	case []string:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
+		}
+	case []bool:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
 		}
-		// add closing bracket to complete the group-items query/script
-		gItemQuery += ")"
-		// add group-item-query to the group/where-script, append in sequence by groupOrder
-		whereQuery += " " + gItemQuery
-		//validate acceptable groupLinkOperators (and || or)
-		grpLinkOp := group.GroupLinkOp
-		groupLnOps := []string{"and", "or"}
-		if grpLinkOp == "" || !ArrayStringContains(groupLnOps, strings.ToLower(grpLinkOp)) {
-			grpLinkOp = groupOperators.AND // default operator
+	case []int:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
 		}
-		// add groupLinkOp, if groupsLen > 1 && groupCount < (groupsLen-emptyGroupCount)
-		if groupsLen > 1 && groupCount < (groupsLen-emptyGroupCount) {
-			whereQuery += " " + strings.ToUpper(grpLinkOp) + " "
+	case []float32:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
 		}
-		// check WHERE script contains at least one condition, otherwise raise an exception
-		if emptyGroupCount == groupsLen {
-			return "", errors.New("no valid where condition specified")
+	case []float64:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
 		}
+	case []int64:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
+		}
+	case []time.Time:
+		for _, v := range fVal {
+			inValues = append(inValues, v)
+		}
+	case []interface{}:
+		// e.g. json-decoded query-params
+		inValues = append(inValues, fVal...)
+	default:
+		return nil, false
 	}
-
-	// if all went well, return valid where script
-	return whereQuery, nil
+	return inValues, true
 }
@@ -0,0 +1,340 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute where-SQL script test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+// whereItemParams function returns the where-params of a single field-name criteria
+func whereItemParams(fieldName string, fieldOperator string, fieldValue interface{}) types.QueryParamType {
+	return types.QueryParamType{
+		{GroupItems: []types.QueryItemType{
+			{GroupItem: map[string]map[string]interface{}{fieldName: {fieldOperator: fieldValue}}},
+		}},
+	}
+}
+
+func TestComputeWhereOperators(t *testing.T) {
+	testCases := []struct {
+		name        string
+		where       types.QueryParamType
+		fieldLength int
+		whereQuery  string
+		fieldValues []interface{}
+		isError     bool
+	}{
+		{
+			name:        "should compute the eq condition, after the calling query placeholders:",
+			where:       whereItemParams("name", "eq", "abi"),
+			fieldLength: 2,
+			whereQuery:  "WHERE (name=$3)",
+			fieldValues: []interface{}{"abi"},
+		},
+		{
+			name:        "should compute the in condition, with a placeholder per value:",
+			where:       whereItemParams("age", "in", []int{21, 30}),
+			whereQuery:  "WHERE (age IN ($1, $2))",
+			fieldValues: []interface{}{21, 30},
+		},
+		{
+			name:        "should escape the LIKE wildcards of the startswith value:",
+			where:       whereItemParams("name", "startswith", `50%_off\`),
+			whereQuery:  "WHERE (name LIKE $1)",
+			fieldValues: []interface{}{`50\%\_off\\%`},
+		},
+		{
+			name:        "should escape the LIKE wildcards of the notincludes value:",
+			where:       whereItemParams("name", "notincludes", "a_b"),
+			whereQuery:  "WHERE (name NOT LIKE $1)",
+			fieldValues: []interface{}{`%a\_b%`},
+		},
+		{
+			name:        "should escape the ILIKE wildcards of the iincludes value:",
+			where:       whereItemParams("name", types.IIncludes, "100%"),
+			whereQuery:  "WHERE (name ILIKE $1)",
+			fieldValues: []interface{}{`%100\%%`},
+		},
+		{
+			name:        "should keep the wildcards of the like pattern:",
+			where:       whereItemParams("name", types.Like, "ab%_"),
+			whereQuery:  "WHERE (name LIKE $1)",
+			fieldValues: []interface{}{"ab%_"},
+		},
+		{
+			name:        "should compute the notilike condition:",
+			where:       whereItemParams("name", types.NotILike, "AB%"),
+			whereQuery:  "WHERE (name NOT ILIKE $1)",
+			fieldValues: []interface{}{"AB%"},
+		},
+		{
+			name:        "should compute the between condition:",
+			where:       whereItemParams("age", types.Between, []int{21, 30}),
+			whereQuery:  "WHERE (age BETWEEN $1 AND $2)",
+			fieldValues: []interface{}{21, 30},
+		},
+		{
+			name:        "should compute the notbetween condition, for the date values:",
+			where:       whereItemParams("log_at", types.NotBetween, []string{"2026-01-01", "2026-12-31"}),
+			whereQuery:  "WHERE (log_at NOT BETWEEN $1 AND $2)",
+			fieldValues: []interface{}{"2026-01-01", "2026-12-31"},
+		},
+		{
+			name:       "should compute the isnull condition, without a placeholder:",
+			where:      whereItemParams("deleted_at", types.IsNull, true),
+			whereQuery: "WHERE (deleted_at IS NULL)",
+		},
+		{
+			name:       "should compute the isnull (false) condition:",
+			where:      whereItemParams("deleted_at", types.IsNull, false),
+			whereQuery: "WHERE (deleted_at IS NOT NULL)",
+		},
+		{
+			name:       "should compute the isnotnull condition:",
+			where:      whereItemParams("deleted_at", types.IsNotNull, true),
+			whereQuery: "WHERE (deleted_at IS NOT NULL)",
+		},
+		{
+			name:        "should compute the regex condition:",
+			where:       whereItemParams("name", types.Regex, "^ab[0-9]+$"),
+			whereQuery:  "WHERE (name ~ $1)",
+			fieldValues: []interface{}{"^ab[0-9]+$"},
+		},
+		{
+			name:        "should compute the notiregex condition:",
+			where:       whereItemParams("name", types.NotIRegex, "^ab"),
+			whereQuery:  "WHERE (name !~* $1)",
+			fieldValues: []interface{}{"^ab"},
+		},
+		{
+			name:        "should compute the arraycontains condition, with a single array placeholder:",
+			where:       whereItemParams("tags", types.ArrayContains, []interface{}{"go", "sql"}),
+			whereQuery:  "WHERE (tags @> $1)",
+			fieldValues: []interface{}{[]string{"go", "sql"}},
+		},
+		{
+			name:        "should compute the arrayoverlaps condition:",
+			where:       whereItemParams("scores", types.ArrayOverlaps, []int{1, 2}),
+			whereQuery:  "WHERE (scores && $1)",
+			fieldValues: []interface{}{[]int{1, 2}},
+		},
+		{
+			name:        "should compute the jsoncontains condition, for the json-encoded value:",
+			where:       whereItemParams("profile", types.JsonContains, map[string]interface{}{"city": "Lagos"}),
+			whereQuery:  "WHERE (profile @> $1::jsonb)",
+			fieldValues: []interface{}{`{"city":"Lagos"}`},
+		},
+		{
+			name:        "should compute the jsoncontainedby condition, for the json string value:",
+			where:       whereItemParams("profile", types.JsonContainedBy, `{"city": "Lagos", "age": 30}`),
+			whereQuery:  "WHERE (profile <@ $1::jsonb)",
+			fieldValues: []interface{}{`{"city": "Lagos", "age": 30}`},
+		},
+		{
+			name:        "should compute the jsonhaskey condition:",
+			where:       whereItemParams("profile", types.JsonHasKey, "city"),
+			whereQuery:  "WHERE (profile ? $1)",
+			fieldValues: []interface{}{"city"},
+		},
+		{
+			name:        "should compute the jsonhasallkeys condition:",
+			where:       whereItemParams("profile", types.JsonHasAllKeys, []string{"city", "age"}),
+			whereQuery:  "WHERE (profile ?& $1::text[])",
+			fieldValues: []interface{}{[]string{"city", "age"}},
+		},
+		{
+			name:        "should compute the jsonpath condition:",
+			where:       whereItemParams("profile", types.JsonPath, "$.age > 21"),
+			whereQuery:  "WHERE (profile @@ $1::jsonpath)",
+			fieldValues: []interface{}{"$.age > 21"},
+		},
+		{
+			name:    "should return an error for the between single value:",
+			where:   whereItemParams("age", types.Between, []int{21}),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the isnull non-boolean value:",
+			where:   whereItemParams("deleted_at", types.IsNull, "yes"),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the arraycontains mixed-type values:",
+			where:   whereItemParams("tags", types.ArrayContains, []interface{}{"go", 1.0}),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the jsoncontains invalid json value:",
+			where:   whereItemParams("profile", types.JsonContains, `{"city": `),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the jsonhasanykeys non-string keys:",
+			where:   whereItemParams("profile", types.JsonHasAnyKeys, []int{1, 2}),
+			isError: true,
+		},
+		{
+			name:    "should return an error for the unknown operator:",
+			where:   whereItemParams("name", "soundslike", "abi"),
+			isError: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := ComputeWhereQuery(tc.where, tc.fieldLength)
+				mctest.AssertEquals(t, err != nil, tc.isError, "where-query error should be as expected")
+				mctest.AssertEquals(t, res.WhereQuery, tc.whereQuery, "where-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "where-query values should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
+
+// nestedWhereParams function returns the where-params of the nested groups, up to the depth (1: the top group)
+func nestedWhereParams(depth int) types.QueryParamType {
+	group := types.QueryGroupType{GroupItems: []types.QueryItemType{
+		{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}}},
+	}}
+	for i := 1; i < depth; i++ {
+		nestedGroup := group
+		group = types.QueryGroupType{GroupItems: []types.QueryItemType{{Group: &nestedGroup}}}
+	}
+	return types.QueryParamType{group}
+}
+
+func TestComputeWhereGroups(t *testing.T) {
+	testCases := []struct {
+		name        string
+		where       types.QueryParamType
+		whereQuery  string
+		fieldValues []interface{}
+		isError     bool
+	}{
+		{
+			name: "should link the groups, in group order, by the group link-operator:",
+			where: types.QueryParamType{
+				{GroupOrder: 2, GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"age": {"gt": 21}}},
+				}},
+				{GroupOrder: 1, GroupLinkOp: "or", GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}}},
+				}},
+			},
+			whereQuery:  "WHERE (name=$1) OR (age>$2)",
+			fieldValues: []interface{}{"abi", 21},
+		},
+		{
+			name: "should compute the nested group and the negated group-item, i.e. a OR (b AND NOT c):",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}}, GroupItemOp: "or"},
+					{GroupItemOrder: 1, Group: &types.QueryGroupType{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"age": {"gte": 21}}, GroupItemOp: "and"},
+						{GroupItem: map[string]map[string]interface{}{"city": {"eq": "Lagos"}}, GroupItemOrder: 1, GroupItemNot: true},
+					}}},
+				}},
+			},
+			whereQuery:  "WHERE (name=$1 OR (age>=$2 AND NOT (city=$3)))",
+			fieldValues: []interface{}{"abi", 21, "Lagos"},
+		},
+		{
+			name: "should negate the nested group-item, i.e. NOT (a OR b):",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItemNot: true, Group: &types.QueryGroupType{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"age": {"lt": 21}}, GroupItemOp: "or"},
+						{GroupItem: map[string]map[string]interface{}{"age": {types.IsNull: true}}, GroupItemOrder: 1},
+					}}},
+				}},
+			},
+			whereQuery:  "WHERE (NOT (age<$1 OR age IS NULL))",
+			fieldValues: []interface{}{21},
+		},
+		{
+			name: "should negate the group, by the group-not:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}}},
+				}},
+				{GroupNot: true, GroupOrder: 1, GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"city": {"eq": "Lagos"}}},
+				}},
+			},
+			whereQuery:  "WHERE (name=$1) AND NOT (city=$2)",
+			fieldValues: []interface{}{"abi", "Lagos"},
+		},
+		{
+			name: "should skip the empty group-item and group:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"eq": nil}}},
+				}},
+				{GroupOrder: 1, GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"city": {"eq": "Lagos"}}},
+				}},
+			},
+			whereQuery:  "WHERE (city=$1)",
+			fieldValues: []interface{}{"Lagos"},
+		},
+		{
+			name:        "should compute the nested groups, up to the maximum depth:",
+			where:       nestedWhereParams(maxWhereDepth),
+			whereQuery:  "WHERE ((((((((((name=$1))))))))))",
+			fieldValues: []interface{}{"abi"},
+		},
+		{
+			name:    "should return an error for the nested groups beyond the maximum depth:",
+			where:   nestedWhereParams(maxWhereDepth + 1),
+			isError: true,
+		},
+		{
+			name: "should return an error for the group-item with both the field-name criteria and nested group:",
+			where: types.QueryParamType{
+				{GroupItems: []types.QueryItemType{
+					{
+						GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}},
+						Group: &types.QueryGroupType{GroupItems: []types.QueryItemType{
+							{GroupItem: map[string]map[string]interface{}{"city": {"eq": "Lagos"}}},
+						}},
+					},
+				}},
+			},
+			isError: true,
+		},
+		{
+			name: "should return an error for the invalid link-operator:",
+			where: types.QueryParamType{
+				{GroupLinkOp: "xor", GroupItems: []types.QueryItemType{
+					{GroupItem: map[string]map[string]interface{}{"name": {"eq": "abi"}}},
+				}},
+			},
+			isError: true,
+		},
+		{
+			name:    "should return an error for the where-params without a valid condition:",
+			where:   whereItemParams("name", "eq", nil),
+			isError: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res, err := ComputeWhereQuery(tc.where, 0)
+				mctest.AssertEquals(t, err != nil, tc.isError, "where-query error should be as expected")
+				mctest.AssertEquals(t, res.WhereQuery, tc.whereQuery, "where-query should be as expected")
+				mctest.AssertStrictEquals(t, res.FieldValues, tc.fieldValues, "where-query values should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/abbeymart/mccrud/types"
 	"github.com/asaskevich/govalidator"
 	"reflect"
+	"regexp"
+	"strings"
+	"time"
 )
 
 type EmailUserNameType struct {
@@ -77,6 +80,42 @@ func ArraySQLInStringValues(arr []string) string {
 	return result
 }
 
+// fieldNameRegex is the acceptable (unquoted) table/field-name pattern
+var fieldNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// IsValidFieldName function determines if the fieldName is a valid (unquoted) table/field-name,
+// for field-names that are not validated against the table-fields, prior to composing SQL scripts
+func IsValidFieldName(fieldName string) bool {
+	return fieldNameRegex.MatchString(fieldName)
+}
+
+// ComputePlaceholders function returns the comma-separated value-placeholders, $startIndex to $(startIndex+count-1)
+func ComputePlaceholders(startIndex int, count int) string {
+	var placeholders []string
+	for i := 0; i < count; i++ {
+		placeholders = append(placeholders, fmt.Sprintf("$%v", startIndex+i))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// ComputeFieldValue function returns the fieldValue as a placeholder/query-parameter value.
+// time, string, boolean, numeric and slice-of-basic-type values are passed as-is (pgx encodes them by column-type),
+// while other composite values (map, struct...) are json-stringified, for json/jsonb table-fields
+func ComputeFieldValue(fieldName string, fieldValue interface{}) (interface{}, error) {
+	switch fieldValue.(type) {
+	case nil, time.Time, string, []byte, bool, int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint,
+		float32, float64, []string, []bool, []int, []int32, []int64, []float32, []float64, []time.Time:
+		return fieldValue, nil
+	default:
+		// json-stringify fieldValue
+		if fVal, err := json.Marshal(fieldValue); err != nil {
+			return nil, errors.New(fmt.Sprintf("field_name: %v | Unknown or Unsupported field-value type: %v", fieldName, err.Error()))
+		} else {
+			return string(fVal), nil
+		}
+	}
+}
+
 // JsonDataETL method converts json inputs to equivalent struct data type specification
 // rec must be a pointer to a type matching the jsonRec
 func JsonDataETL(jsonRec []byte, rec interface{}) error {
@@ -0,0 +1,258 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: validate the query, sort, project and action params against the model (field-names and types)
+
+package helper
+
+import (
+	"fmt"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/datatypes"
+	"github.com/abbeymart/mctypes/operators"
+	"strings"
+	"time"
+)
+
+// field-kinds, i.e. field-types by the permitted operators and values
+const (
+	fieldKindString   = "string"
+	fieldKindUUID     = "uuid"
+	fieldKindNumber   = "number"
+	fieldKindBoolean  = "boolean"
+	fieldKindDateTime = "datetime"
+	fieldKindJson     = "json"
+	fieldKindArray    = "array"
+)
+
+// computeFieldKind function returns the field-kind of the model field-type
+func computeFieldKind(fieldType string) string {
+	switch strings.ToLower(fieldType) {
+	case datatypes.UUID, datatypes.UUID3, datatypes.UUID4, datatypes.UUID5:
+		return fieldKindUUID
+	case datatypes.Integer, datatypes.Positive, datatypes.Natural, datatypes.Negative, datatypes.Port, datatypes.BigInt,
+		datatypes.Number, datatypes.Decimal, datatypes.BigFloat, datatypes.Float, datatypes.Float32, datatypes.Float64,
+		datatypes.Latitude, datatypes.Longitude:
+		return fieldKindNumber
+	case datatypes.Boolean:
+		return fieldKindBoolean
+	case datatypes.DateTime, datatypes.TimeStampZ, datatypes.TimeStamp, datatypes.Date, datatypes.Time:
+		return fieldKindDateTime
+	case datatypes.JSON, datatypes.Object, datatypes.Map, datatypes.Set, datatypes.Array, datatypes.ArrayOfStruct,
+		datatypes.ArrayOfMap, datatypes.ArrayOfArray:
+		return fieldKindJson
+	case datatypes.ArrayOfString, datatypes.ArrayOfNumber, datatypes.ArrayOfBoolean:
+		return fieldKindArray
+	default:
+		return fieldKindString
+	}
+}
+
+// operatorKinds maps the where-operators to the permitted field-kinds (all field-kinds, if not specified)
+var operatorKinds = map[string][]string{
+	operators.LessThan:            {fieldKindString, fieldKindNumber, fieldKindDateTime},
+	operators.LessThanOrEquals:    {fieldKindString, fieldKindNumber, fieldKindDateTime},
+	operators.GreaterThan:         {fieldKindString, fieldKindNumber, fieldKindDateTime},
+	operators.GreaterThanOrEquals: {fieldKindString, fieldKindNumber, fieldKindDateTime},
+	types.Between:                 {fieldKindString, fieldKindNumber, fieldKindDateTime},
+	types.NotBetween:              {fieldKindString, fieldKindNumber, fieldKindDateTime},
+	types.Regex:                   {fieldKindString},
+	types.IRegex:                  {fieldKindString},
+	types.NotRegex:                {fieldKindString},
+	types.NotIRegex:               {fieldKindString},
+	types.ArrayContains:           {fieldKindArray},
+	types.ArrayContainedBy:        {fieldKindArray},
+	types.ArrayOverlaps:           {fieldKindArray},
+	types.JsonContains:            {fieldKindJson},
+	types.JsonContainedBy:         {fieldKindJson},
+	types.JsonHasKey:              {fieldKindJson},
+	types.JsonHasAnyKeys:          {fieldKindJson},
+	types.JsonHasAllKeys:          {fieldKindJson},
+	types.JsonPath:                {fieldKindJson},
+	types.JsonPathExists:          {fieldKindJson},
+}
+
+// isFieldKindValue function determines if the (scalar) fieldValue is valid for the field-kind
+func isFieldKindValue(fieldKind string, fieldValue interface{}) bool {
+	switch fieldKind {
+	case fieldKindNumber:
+		switch fieldValue.(type) {
+		case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint, float32, float64:
+			return true
+		}
+		return false
+	case fieldKindBoolean:
+		_, ok := fieldValue.(bool)
+		return ok
+	case fieldKindDateTime:
+		switch fieldValue.(type) {
+		case time.Time, string:
+			return true
+		}
+		return false
+	case fieldKindString, fieldKindUUID:
+		_, ok := fieldValue.(string)
+		return ok
+	default:
+		return true
+	}
+}
+
+// ComputeModelFields function returns the model field-kinds, by field-name, including the id field and the time,
+// actor and active stamps, if specified. The extraFields (e.g. deleted_at and deleted_by) are included, by field-type.
+func ComputeModelFields(model mctypes.ModelType, extraFields map[string]string) map[string]string {
+	modelFields := map[string]string{"id": fieldKindUUID}
+	for fieldName, fieldDesc := range model.RecordDesc {
+		modelFields[fieldName] = computeFieldKind(fieldDesc.FieldType)
+	}
+	if model.TimeStamp {
+		modelFields["created_at"] = fieldKindDateTime
+		modelFields["updated_at"] = fieldKindDateTime
+	}
+	if model.ActorStamp {
+		modelFields["created_by"] = fieldKindString
+		modelFields["updated_by"] = fieldKindString
+	}
+	if model.ActiveStamp {
+		modelFields["is_active"] = fieldKindBoolean
+	}
+	for fieldName, fieldType := range extraFields {
+		modelFields[fieldName] = computeFieldKind(fieldType)
+	}
+	return modelFields
+}
+
+// validateWhereItem function validates the where field-name, operator and value against the model field-kind,
+// and returns the validation error message, or "" if valid
+func validateWhereItem(fieldKind string, fieldOperator string, fieldValue interface{}) string {
+	fieldOperator = strings.ToLower(fieldOperator)
+	if likeOp, ok := likeOperators[fieldOperator]; ok {
+		if fieldKind != fieldKindString {
+			return fmt.Sprintf("operator [%v] is not permitted for the %v field", fieldOperator, fieldKind)
+		}
+		if _, ok = fieldValue.(string); !ok {
+			return fmt.Sprintf("operator [%v] value must be a string (%v pattern)", fieldOperator, likeOp.sqlOperator)
+		}
+		return ""
+	}
+	if fieldKinds, ok := operatorKinds[fieldOperator]; ok && !ArrayStringContains(fieldKinds, fieldKind) {
+		return fmt.Sprintf("operator [%v] is not permitted for the %v field", fieldOperator, fieldKind)
+	}
+	switch fieldOperator {
+	case operators.Equals, operators.NotEquals, operators.LessThan, operators.LessThanOrEquals,
+		operators.GreaterThan, operators.GreaterThanOrEquals:
+		if fieldKind != fieldKindJson && fieldKind != fieldKindArray && !isFieldKindValue(fieldKind, fieldValue) {
+			return fmt.Sprintf("value [%v] is not a valid %v value", fieldValue, fieldKind)
+		}
+	case operators.In, operators.NotIn, types.Between, types.NotBetween:
+		values, ok := inFieldValues(fieldValue)
+		if !ok {
+			return fmt.Sprintf("operator [%v] value must be an array of values", fieldOperator)
+		}
+		for _, value := range values {
+			if !isFieldKindValue(fieldKind, value) {
+				return fmt.Sprintf("value [%v] is not a valid %v value", value, fieldKind)
+			}
+		}
+	case types.Regex, types.IRegex, types.NotRegex, types.NotIRegex:
+		if _, ok := fieldValue.(string); !ok {
+			return fmt.Sprintf("operator [%v] value must be a string (regular expression)", fieldOperator)
+		}
+	}
+	return ""
+}
+
+// validateWhereGroups function validates the where-groups' (including nested groups) field-names, operators and
+// values against the model fields, and adds the errors to the validateErrors, by paramName.fieldName
+func validateWhereGroups(groups types.QueryParamType, modelFields map[string]string, paramName string, validateErrors types.MessageObject) {
+	for _, group := range groups {
+		for _, gItem := range group.GroupItems {
+			if gItem.Group != nil {
+				validateWhereGroups(types.QueryParamType{*gItem.Group}, modelFields, paramName, validateErrors)
+			}
+			for fieldName, opVal := range gItem.GroupItem {
+				errorKey := paramName + "." + fieldName
+				if _, ok := opVal[types.Search]; ok && len(opVal) == 1 {
+					// search field-names (comma-separated)
+					for _, searchField := range strings.Split(fieldName, ",") {
+						if fieldKind := modelFields[strings.TrimSpace(searchField)]; fieldKind != fieldKindString {
+							validateErrors[errorKey] = fmt.Sprintf("search field-name [%v] must be a string/text field", strings.TrimSpace(searchField))
+						}
+					}
+					continue
+				}
+				fieldKind, ok := modelFields[fieldName]
+				if !ok {
+					validateErrors[errorKey] = fmt.Sprintf("unknown field-name [%v]", fieldName)
+					continue
+				}
+				for fieldOperator, fieldValue := range opVal {
+					if fieldValue == nil {
+						continue
+					}
+					if message := validateWhereItem(fieldKind, fieldOperator, fieldValue); message != "" {
+						validateErrors[errorKey] = message
+					}
+				}
+			}
+		}
+	}
+}
+
+// ValidateQueryParams function validates the query-params (where-groups) field-names, operators and values
+// against the model fields (ComputeModelFields), i.e. unknown field-names and operator/type mismatches
+func ValidateQueryParams(queryParams types.QueryParamType, modelFields map[string]string) types.ValidateResponseType {
+	validateErrors := types.MessageObject{}
+	validateWhereGroups(queryParams, modelFields, "queryParams", validateErrors)
+	return types.ValidateResponseType{Ok: len(validateErrors) < 1, Errors: validateErrors}
+}
+
+// ValidateTableFields function validates the table-fields, i.e. the create, update and select field-names that reach
+// the SQL scripts, against the model fields (ComputeModelFields) and the search-rank (virtual) field, and returns the
+// validation errors, by param-name and field-name, e.g. tableFields.age
+func ValidateTableFields(tableFields []string, modelFields map[string]string) types.ValidateResponseType {
+	validateErrors := types.MessageObject{}
+	for _, fieldName := range tableFields {
+		if _, ok := modelFields[fieldName]; !ok && fieldName != types.SearchRankField {
+			validateErrors["tableFields."+fieldName] = fmt.Sprintf("unknown field-name [%v]", fieldName)
+		}
+	}
+	return types.ValidateResponseType{Ok: len(validateErrors) < 1, Errors: validateErrors}
+}
+
+// ValidateParams function validates the query, sort (sort-params and sort-items), project and action params
+// against the model fields (ComputeModelFields) and the search-rank (virtual) field, and returns the validation errors, by param-name and field-name,
+// e.g. queryParams.age or actionParams[0].age
+func ValidateParams(params types.CrudParamsType, modelFields map[string]string) types.ValidateResponseType {
+	validateErrors := types.MessageObject{}
+	validateWhereGroups(params.QueryParams, modelFields, "queryParams", validateErrors)
+	for fieldName := range params.SortParams {
+		if _, ok := modelFields[fieldName]; !ok && fieldName != types.SearchRankField {
+			validateErrors["sortParams."+fieldName] = fmt.Sprintf("unknown field-name [%v]", fieldName)
+		}
+	}
+	for _, sortItem := range params.SortItems {
+		if _, ok := modelFields[sortItem.FieldName]; !ok && sortItem.FieldName != types.SearchRankField {
+			validateErrors["sortItems."+sortItem.FieldName] = fmt.Sprintf("unknown field-name [%v]", sortItem.FieldName)
+		}
+	}
+	for fieldName := range params.ProjectParams {
+		if _, ok := modelFields[fieldName]; !ok && fieldName != types.SearchRankField {
+			validateErrors["projectParams."+fieldName] = fmt.Sprintf("unknown field-name [%v]", fieldName)
+		}
+	}
+	for recNum, rec := range params.ActionParams {
+		for fieldName, fieldValue := range rec {
+			errorKey := fmt.Sprintf("actionParams[%v].%v", recNum, fieldName)
+			fieldKind, ok := modelFields[fieldName]
+			if !ok {
+				validateErrors[errorKey] = fmt.Sprintf("unknown field-name [%v]", fieldName)
+				continue
+			}
+			if fieldValue != nil && fieldKind != fieldKindJson && fieldKind != fieldKindArray && !isFieldKindValue(fieldKind, fieldValue) {
+				validateErrors[errorKey] = fmt.Sprintf("value [%v] is not a valid %v value", fieldValue, fieldKind)
+			}
+		}
+	}
+	return types.ValidateResponseType{Ok: len(validateErrors) < 1, Errors: validateErrors}
+}
@@ -0,0 +1,170 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: validate the query, sort, project and action params test cases
+
+package helper
+
+import (
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctest"
+	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/datatypes"
+	"testing"
+)
+
+func TestValidateParams(t *testing.T) {
+	model := mctypes.ModelType{
+		TableName: "users",
+		RecordDesc: mctypes.RecordDescType{
+			"name":    {FieldType: datatypes.String},
+			"age":     {FieldType: datatypes.Integer},
+			"active":  {FieldType: datatypes.Boolean},
+			"profile": {FieldType: datatypes.JSON},
+			"tags":    {FieldType: datatypes.ArrayOfString},
+		},
+		TimeStamp: true,
+	}
+	modelFields := ComputeModelFields(model, map[string]string{types.DeletedAtField: datatypes.TimeStampZ})
+	testCases := []struct {
+		name   string
+		params types.CrudParamsType
+		errors types.MessageObject
+	}{
+		{
+			name: "should accept the valid query, sort, project and action params:",
+			params: types.CrudParamsType{
+				QueryParams: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"name": {"startswith": "ab"}}},
+						{GroupItem: map[string]map[string]interface{}{"age": {types.Between: []int{21, 30}}}},
+						{GroupItem: map[string]map[string]interface{}{"created_at": {"gte": "2026-01-01"}}},
+						{GroupItem: map[string]map[string]interface{}{types.DeletedAtField: {types.IsNull: true}}},
+						{Group: &types.QueryGroupType{GroupItems: []types.QueryItemType{
+							{GroupItem: map[string]map[string]interface{}{"tags": {types.ArrayContains: []string{"go"}}}},
+							{GroupItem: map[string]map[string]interface{}{"profile": {types.JsonHasKey: "city"}}},
+						}}},
+					}},
+				},
+				SortItems:     types.SortItemsType{{FieldName: "age", Order: -1}},
+				SortParams:    types.SortParamType{"name": 1},
+				ProjectParams: types.ProjectParamType{"name": true, "age": true},
+				ActionParams:  types.ActionParamsType{{"name": "abi", "age": 30, "active": true, "profile": map[string]interface{}{"city": "Lagos"}}},
+			},
+			errors: types.MessageObject{},
+		},
+		{
+			name: "should reject the unknown field-names, including the nested groups:",
+			params: types.CrudParamsType{
+				QueryParams: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{
+						{Group: &types.QueryGroupType{GroupItems: []types.QueryItemType{
+							{GroupItem: map[string]map[string]interface{}{"password": {"eq": "secret"}}},
+						}}},
+					}},
+				},
+				SortItems:     types.SortItemsType{{FieldName: "salary", Order: 1}},
+				SortParams:    types.SortParamType{"rank": 1},
+				ProjectParams: types.ProjectParamType{"email": true},
+				ActionParams:  types.ActionParamsType{{"name": "abi"}, {"role": "admin"}},
+			},
+			errors: types.MessageObject{
+				"queryParams.password": "unknown field-name [password]",
+				"sortItems.salary":     "unknown field-name [salary]",
+				"sortParams.rank":      "unknown field-name [rank]",
+				"projectParams.email":  "unknown field-name [email]",
+				"actionParams[1].role": "unknown field-name [role]",
+			},
+		},
+		{
+			name: "should reject the operator and value type mismatches:",
+			params: types.CrudParamsType{
+				QueryParams: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"age": {"startswith": "2"}}},
+						{GroupItem: map[string]map[string]interface{}{"name": {types.ArrayContains: []string{"ab"}}}},
+						{GroupItem: map[string]map[string]interface{}{"active": {"eq": "yes"}}},
+						{GroupItem: map[string]map[string]interface{}{"created_at": {"in": []int{1}}}},
+					}},
+				},
+				ActionParams: types.ActionParamsType{{"age": "thirty"}},
+			},
+			errors: types.MessageObject{
+				"queryParams.age":        "operator [startswith] is not permitted for the number field",
+				"queryParams.name":       "operator [arraycontains] is not permitted for the string field",
+				"queryParams.active":     "value [yes] is not a valid boolean value",
+				"queryParams.created_at": "value [1] is not a valid datetime value",
+				"actionParams[0].age":    "value [thirty] is not a valid number value",
+			},
+		},
+		{
+			name: "should accept the search-rank sort and projection, and the search string fields only:",
+			params: types.CrudParamsType{
+				QueryParams: types.QueryParamType{
+					{GroupItems: []types.QueryItemType{
+						{GroupItem: map[string]map[string]interface{}{"name, age": {types.Search: "abi"}}},
+					}},
+				},
+				SortItems:     types.SortItemsType{{FieldName: types.SearchRankField, Order: -1}},
+				ProjectParams: types.ProjectParamType{types.SearchRankField: true},
+			},
+			errors: types.MessageObject{
+				"queryParams.name, age": "search field-name [age] must be a string/text field",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				res := ValidateParams(tc.params, modelFields)
+				mctest.AssertEquals(t, res.Ok, len(tc.errors) < 1, "validate-params ok should be as expected")
+				mctest.AssertStrictEquals(t, res.Errors, tc.errors, "validate-params errors should be as expected")
+			},
+		})
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject the unknown tableFields field-names, except the search-rank:",
+		TestFunc: func() {
+			res := ValidateTableFields([]string{"name", "created_at", types.SearchRankField, "password"}, modelFields)
+			mctest.AssertEquals(t, res.Ok, false, "validate-table-fields ok should be false")
+			mctest.AssertStrictEquals(t, res.Errors, types.MessageObject{"tableFields.password": "unknown field-name [password]"}, "validate-table-fields errors should be as expected")
+		},
+	})
+
+	sortCases := []struct {
+		name      string
+		sortItems types.SortItemsType
+		sortQuery string
+		isError   bool
+	}{
+		{
+			name:      "should compute the order-by script, with the id tie-breaker:",
+			sortItems: types.SortItemsType{{FieldName: "age", Order: -1, Nulls: "last"}, {FieldName: "name", Order: 1}},
+			sortQuery: "ORDER BY age DESC NULLS LAST, name ASC, id ASC",
+		},
+		{
+			name:      "should reject the sort by a non-projected field:",
+			sortItems: types.SortItemsType{{FieldName: "created_at", Order: 1}},
+			isError:   true,
+		},
+		{
+			name:      "should reject the sort by an injected field-name:",
+			sortItems: types.SortItemsType{{FieldName: "age; DROP TABLE users", Order: 1}},
+			isError:   true,
+		},
+	}
+	for _, tc := range sortCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				sortQuery, err := ComputeSortQuery(tc.sortItems, []string{"id", "name", "age"})
+				mctest.AssertEquals(t, err != nil, tc.isError, "sort-query error should be as expected")
+				mctest.AssertEquals(t, sortQuery, tc.sortQuery, "sort-query should be as expected")
+			},
+		})
+	}
+	mctest.PostTestResult()
+}
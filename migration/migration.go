@@ -0,0 +1,346 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: versioned schema migrations: up/down, status, checksums and advisory lock
+
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctypes"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MigrationFuncType is the Go-function migration (up or down), executed in the migration transaction
+type MigrationFuncType func(ctx context.Context, tx pgx.Tx) error
+
+// MigrationType is a versioned migration, with the up/down SQL scripts or Go-functions (the functions take precedence)
+type MigrationType struct {
+	Version int64
+	Name    string
+	UpSql   string
+	DownSql string
+	Up      MigrationFuncType
+	Down    MigrationFuncType
+}
+
+// Checksum method returns the migration checksum (sha256), from the version, name and the up/down SQL scripts,
+// to detect the changes to the applied migrations (Go-function migrations: the version and name only)
+func (migration MigrationType) Checksum() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v\n%v\n%v\n--down--\n%v", migration.Version, migration.Name, migration.UpSql, migration.DownSql)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatusType is the migration status: applied (AppliedAt), changed (checksum mismatch) or
+// missing (applied, but not in the migrations list)
+type MigrationStatusType struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Changed   bool
+	Missing   bool
+}
+
+type MigratorOptionsType struct {
+	TableName string // default: schema_migrations
+	LockKey   int64  // advisory lock key, default: hash of the TableName
+}
+
+// Migrator applies/reverts the migrations, in version order
+type Migrator struct {
+	AppDb      *pgxpool.Pool
+	TableName  string
+	LockKey    int64
+	Migrations []MigrationType
+}
+
+// NewMigrator constructor returns a new migrator-instance, for the migrations (sorted by version)
+func NewMigrator(appDb *pgxpool.Pool, migrations []MigrationType, options MigratorOptionsType) (*Migrator, error) {
+	if appDb == nil {
+		return nil, errors.New("db connection is required for the migrations")
+	}
+	tableName := options.TableName
+	if tableName == "" {
+		tableName = "schema_migrations"
+	}
+	if !helper.IsValidFieldName(tableName) {
+		return nil, errors.New(fmt.Sprintf("invalid migrations table-name [%v]", tableName))
+	}
+	lockKey := options.LockKey
+	if lockKey == 0 {
+		for _, c := range tableName {
+			lockKey = lockKey*31 + int64(c)
+		}
+	}
+	sortedMigrations := append([]MigrationType{}, migrations...)
+	sort.SliceStable(sortedMigrations, func(i, j int) bool {
+		return sortedMigrations[i].Version < sortedMigrations[j].Version
+	})
+	for i, migration := range sortedMigrations {
+		if migration.Version <= 0 {
+			return nil, errors.New(fmt.Sprintf("migration [%v] version must be greater than 0", migration.Name))
+		}
+		if i > 0 && sortedMigrations[i-1].Version == migration.Version {
+			return nil, errors.New(fmt.Sprintf("migration version [%v] must be unique", migration.Version))
+		}
+		if migration.Up == nil && migration.UpSql == "" {
+			return nil, errors.New(fmt.Sprintf("migration [%v] up script or function is required", migration.Version))
+		}
+	}
+	return &Migrator{
+		AppDb:      appDb,
+		TableName:  tableName,
+		LockKey:    lockKey,
+		Migrations: sortedMigrations,
+	}, nil
+}
+
+// migrationFileRegex is the migration file-name pattern: NNNN_name.up.sql | NNNN_name.down.sql
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// LoadMigrationFiles function loads the SQL migrations from the dir files, named NNNN_name.up.sql and NNNN_name.down.sql
+func LoadMigrationFiles(dir string) ([]MigrationType, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	migrations := map[int64]*MigrationType{}
+	for _, file := range files {
+		matches := migrationFileRegex.FindStringSubmatch(file.Name())
+		if file.IsDir() || matches == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid migration file-name [%v] version: %v", file.Name(), err.Error()))
+		}
+		script, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &MigrationType{Version: version, Name: matches[2]}
+			migrations[version] = migration
+		} else if migration.Name != matches[2] {
+			return nil, errors.New(fmt.Sprintf("migration version [%v] must be unique: %v, %v", version, migration.Name, matches[2]))
+		}
+		if matches[3] == "up" {
+			migration.UpSql = string(script)
+		} else {
+			migration.DownSql = string(script)
+		}
+	}
+	var result []MigrationType
+	for _, migration := range migrations {
+		result = append(result, *migration)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+// ModelMigration function returns the create-table (up) / drop-table (down) migration for the model
+func ModelMigration(version int64, name string, model mctypes.ModelType) (MigrationType, error) {
+	return ModelMigrationWithOptions(version, name, model, types.TableOptionsType{})
+}
+
+// ModelMigrationWithOptions function returns the create-table (up) / drop-table (down) migration for the model and
+// the table options (e.g. SoftDelete)
+func ModelMigrationWithOptions(version int64, name string, model mctypes.ModelType, options types.TableOptionsType) (MigrationType, error) {
+	upSql, err := helper.CreateTableQueryWithOptions(model, options)
+	if err != nil {
+		return MigrationType{}, err
+	}
+	return MigrationType{
+		Version: version,
+		Name:    name,
+		UpSql:   upSql,
+		DownSql: fmt.Sprintf("DROP TABLE %v", model.TableName),
+	}, nil
+}
+
+// appliedMigrationType is the schema_migrations record
+type appliedMigrationType struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// withLock method acquires a connection, creates the migrations table (if not exists) and runs the task
+// under the (session) advisory lock, to prevent concurrent migrations
+func (m *Migrator) withLock(ctx context.Context, task func(conn *pgxpool.Conn) error) error {
+	conn, err := m.AppDb.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", m.LockKey); err != nil {
+		return errors.New(fmt.Sprintf("error acquiring the migrations lock: %v", err.Error()))
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", m.LockKey)
+	if _, err = conn.Exec(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (version BIGINT NOT NULL PRIMARY KEY, "+
+		"name VARCHAR(255) NOT NULL, checksum VARCHAR(64) NOT NULL, applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP)", m.TableName)); err != nil {
+		return errors.New(fmt.Sprintf("error creating the migrations table: %v", err.Error()))
+	}
+	return task(conn)
+}
+
+// readApplied method reads the applied migrations, by version
+func (m *Migrator) readApplied(ctx context.Context, conn *pgxpool.Conn) (map[int64]appliedMigrationType, error) {
+	rows, err := conn.Query(ctx, fmt.Sprintf("SELECT version, name, checksum, applied_at FROM %v ORDER BY version", m.TableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int64]appliedMigrationType{}
+	for rows.Next() {
+		var rec appliedMigrationType
+		if err = rows.Scan(&rec.Version, &rec.Name, &rec.Checksum, &rec.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[rec.Version] = rec
+	}
+	return applied, rows.Err()
+}
+
+// computeStatus method computes the migrations' status, from the applied migrations
+func (m *Migrator) computeStatus(applied map[int64]appliedMigrationType) []MigrationStatusType {
+	var status []MigrationStatusType
+	known := map[int64]bool{}
+	for _, migration := range m.Migrations {
+		known[migration.Version] = true
+		migrationStatus := MigrationStatusType{Version: migration.Version, Name: migration.Name}
+		if rec, ok := applied[migration.Version]; ok {
+			migrationStatus.Applied = true
+			migrationStatus.AppliedAt = rec.AppliedAt
+			migrationStatus.Changed = rec.Checksum != migration.Checksum()
+		}
+		status = append(status, migrationStatus)
+	}
+	for version, rec := range applied {
+		if !known[version] {
+			status = append(status, MigrationStatusType{Version: version, Name: rec.Name, Applied: true, AppliedAt: rec.AppliedAt, Missing: true})
+		}
+	}
+	sort.SliceStable(status, func(i, j int) bool {
+		return status[i].Version < status[j].Version
+	})
+	return status
+}
+
+// Status method returns the status of the migrations, including the applied migrations not in the migrations list
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatusType, error) {
+	var status []MigrationStatusType
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.readApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		status = m.computeStatus(applied)
+		return nil
+	})
+	return status, err
+}
+
+// runMigration method runs the migration up/down function or SQL script and records/removes the migration version,
+// in a transaction
+func (m *Migrator) runMigration(ctx context.Context, conn *pgxpool.Conn, migration MigrationType, up bool) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+	migrationFunc, migrationSql := migration.Down, migration.DownSql
+	if up {
+		migrationFunc, migrationSql = migration.Up, migration.UpSql
+	}
+	if migrationFunc != nil {
+		err = migrationFunc(ctx, tx)
+	} else if migrationSql != "" {
+		_, err = tx.Exec(ctx, migrationSql)
+	} else {
+		err = errors.New("no migration script or function specified")
+	}
+	if err != nil {
+		return errors.New(fmt.Sprintf("migration [%v_%v] error: %v", migration.Version, migration.Name, err.Error()))
+	}
+	if up {
+		_, err = tx.Exec(ctx, fmt.Sprintf("INSERT INTO %v(version, name, checksum) VALUES ($1, $2, $3)", m.TableName), migration.Version, migration.Name, migration.Checksum())
+	} else {
+		_, err = tx.Exec(ctx, fmt.Sprintf("DELETE FROM %v WHERE version = $1", m.TableName), migration.Version)
+	}
+	if err != nil {
+		return errors.New(fmt.Sprintf("migration [%v_%v] version-record error: %v", migration.Version, migration.Name, err.Error()))
+	}
+	return tx.Commit(ctx)
+}
+
+// Up method applies the pending migrations, in version order, up to the targetVersion (all, if 0), and returns the
+// applied versions. The migrations are not applied, if any applied migration has changed (checksum mismatch).
+func (m *Migrator) Up(ctx context.Context, targetVersion int64) ([]int64, error) {
+	var versions []int64
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.readApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migrationStatus := range m.computeStatus(applied) {
+			if migrationStatus.Changed {
+				return errors.New(fmt.Sprintf("applied migration [%v_%v] has changed (checksum mismatch)", migrationStatus.Version, migrationStatus.Name))
+			}
+		}
+		for _, migration := range m.Migrations {
+			if targetVersion > 0 && migration.Version > targetVersion {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err = m.runMigration(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			versions = append(versions, migration.Version)
+		}
+		return nil
+	})
+	return versions, err
+}
+
+// Down method reverts the last (steps) applied migrations, in reverse version order, and returns the reverted versions
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int64, error) {
+	var versions []int64
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.readApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.Migrations) - 1; i >= 0 && len(versions) < steps; i-- {
+			migration := m.Migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err = m.runMigration(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			versions = append(versions, migration.Version)
+		}
+		return nil
+	})
+	return versions, err
+}
@@ -0,0 +1,142 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: migration files loading, checksums and status test cases
+
+package migration
+
+import (
+	"github.com/abbeymart/mctest"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMigration(t *testing.T) {
+	loadCases := []struct {
+		name       string
+		files      map[string]string
+		migrations []MigrationType
+		isError    bool
+	}{
+		{
+			name: "should load the up/down migration files, in version order:",
+			files: map[string]string{
+				"0002_add_age.up.sql":        "ALTER TABLE users ADD COLUMN age INTEGER",
+				"0002_add_age.down.sql":      "ALTER TABLE users DROP COLUMN age",
+				"0001_create_users.up.sql":   "CREATE TABLE users (id UUID PRIMARY KEY)",
+				"0001_create_users.down.sql": "DROP TABLE users",
+				"0010_seed_users.up.sql":     "INSERT INTO users(id) VALUES (gen_random_uuid())",
+				"README.md":                  "migration files: NNNN_name.up.sql | NNNN_name.down.sql",
+				"0003_draft.sql":             "SELECT 1",
+			},
+			migrations: []MigrationType{
+				{Version: 1, Name: "create_users", UpSql: "CREATE TABLE users (id UUID PRIMARY KEY)", DownSql: "DROP TABLE users"},
+				{Version: 2, Name: "add_age", UpSql: "ALTER TABLE users ADD COLUMN age INTEGER", DownSql: "ALTER TABLE users DROP COLUMN age"},
+				{Version: 10, Name: "seed_users", UpSql: "INSERT INTO users(id) VALUES (gen_random_uuid())"},
+			},
+		},
+		{
+			name: "should return an error for the duplicate migration version:",
+			files: map[string]string{
+				"0001_create_users.up.sql": "CREATE TABLE users (id UUID PRIMARY KEY)",
+				"1_create_roles.up.sql":    "CREATE TABLE roles (id UUID PRIMARY KEY)",
+			},
+			isError: true,
+		},
+	}
+	for _, tc := range loadCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				dir, err := ioutil.TempDir("", "mccrud-migrations")
+				if err != nil {
+					t.Fatalf("error creating the migrations dir: %v", err.Error())
+				}
+				defer os.RemoveAll(dir)
+				for fileName, script := range tc.files {
+					if err = ioutil.WriteFile(filepath.Join(dir, fileName), []byte(script), 0644); err != nil {
+						t.Fatalf("error writing the migration file: %v", err.Error())
+					}
+				}
+				migrations, err := LoadMigrationFiles(dir)
+				mctest.AssertEquals(t, err != nil, tc.isError, "load-migration-files error should be as expected")
+				mctest.AssertEquals(t, len(migrations), len(tc.migrations), "migrations count should be as expected")
+				for i, migration := range tc.migrations {
+					if i >= len(migrations) {
+						break
+					}
+					mctest.AssertEquals(t, migrations[i].Version, migration.Version, "migration version should be as expected")
+					mctest.AssertEquals(t, migrations[i].Name, migration.Name, "migration name should be as expected")
+					mctest.AssertEquals(t, migrations[i].UpSql, migration.UpSql, "migration up script should be as expected")
+					mctest.AssertEquals(t, migrations[i].DownSql, migration.DownSql, "migration down script should be as expected")
+					mctest.AssertEquals(t, migrations[i].Checksum(), migration.Checksum(), "migration checksum should be as expected")
+				}
+			},
+		})
+	}
+
+	migration := MigrationType{Version: 1, Name: "create_users", UpSql: "CREATE TABLE users (id UUID PRIMARY KEY)", DownSql: "DROP TABLE users"}
+	checksumCases := []struct {
+		name      string
+		migration MigrationType
+		changed   bool
+	}{
+		{
+			name:      "should compute the same checksum for the same migration:",
+			migration: migration,
+		},
+		{
+			name:      "should compute a different checksum for the changed up script:",
+			migration: MigrationType{Version: 1, Name: "create_users", UpSql: "CREATE TABLE users (id UUID PRIMARY KEY, age INTEGER)", DownSql: "DROP TABLE users"},
+			changed:   true,
+		},
+		{
+			name:      "should compute a different checksum for the changed down script:",
+			migration: MigrationType{Version: 1, Name: "create_users", UpSql: "CREATE TABLE users (id UUID PRIMARY KEY)"},
+			changed:   true,
+		},
+		{
+			name:      "should compute a different checksum for the changed version:",
+			migration: MigrationType{Version: 2, Name: "create_users", UpSql: "CREATE TABLE users (id UUID PRIMARY KEY)", DownSql: "DROP TABLE users"},
+			changed:   true,
+		},
+	}
+	for _, tc := range checksumCases {
+		tc := tc
+		mctest.McTest(mctest.OptionValue{
+			Name: tc.name,
+			TestFunc: func() {
+				mctest.AssertEquals(t, len(tc.migration.Checksum()), 64, "migration checksum should be a sha256 hex")
+				mctest.AssertEquals(t, tc.migration.Checksum() != migration.Checksum(), tc.changed, "migration checksum change should be as expected")
+			},
+		})
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the applied, changed and missing migrations status:",
+		TestFunc: func() {
+			appliedAt := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+			changedMigration := MigrationType{Version: 2, Name: "add_age", UpSql: "ALTER TABLE users ADD COLUMN age INTEGER"}
+			m := &Migrator{Migrations: []MigrationType{
+				migration,
+				changedMigration,
+				{Version: 3, Name: "add_city", UpSql: "ALTER TABLE users ADD COLUMN city VARCHAR(255)"},
+			}}
+			status := m.computeStatus(map[int64]appliedMigrationType{
+				1: {Version: 1, Name: "create_users", Checksum: migration.Checksum(), AppliedAt: appliedAt},
+				2: {Version: 2, Name: "add_age", Checksum: "previous-checksum", AppliedAt: appliedAt},
+				9: {Version: 9, Name: "removed", Checksum: "removed-checksum", AppliedAt: appliedAt},
+			})
+			mctest.AssertStrictEquals(t, status, []MigrationStatusType{
+				{Version: 1, Name: "create_users", Applied: true, AppliedAt: appliedAt},
+				{Version: 2, Name: "add_age", Applied: true, AppliedAt: appliedAt, Changed: true},
+				{Version: 3, Name: "add_city"},
+				{Version: 9, Name: "removed", Applied: true, AppliedAt: appliedAt, Missing: true},
+			}, "migrations status should be as expected")
+		},
+	})
+	mctest.PostTestResult()
+}
@@ -0,0 +1,122 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: child-table references (sub-items) checks and recursive delete, for the delete operations
+
+package mccrud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/jackc/pgx/v4"
+	"strings"
+)
+
+// maxReferenceDepth is the maximum depth of the child-tables' references, for the recursive delete
+const maxReferenceDepth = 10
+
+// deletedSubItems is the deleted sub-items (count and records, for the LogDelete audit-log) of the child-table
+type deletedSubItems struct {
+	tableName   string
+	recordCount int
+	records     []interface{}
+}
+
+// tableReferences method returns the (foreign-key) references of the childTables (all referencing tables, if not
+// specified) to the parentTable, excluding the ParentTables and the excludeTables, e.g. the recursive-delete path
+func (crud *Crud) tableReferences(db dbQueryer, parentTable string, childTables []string, excludeTables []string) ([]types.TableReferenceType, error) {
+	referencesQuery, fieldValues, err := helper.ComputeReferencesQuery(parentTable, childTables)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(context.Background(), referencesQuery, fieldValues...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var references []types.TableReferenceType
+	var referenceTables []string
+	for rows.Next() {
+		reference := types.TableReferenceType{ParentTable: parentTable}
+		if err = rows.Scan(&reference.TableName, &reference.FieldName, &reference.ParentField); err != nil {
+			return nil, err
+		}
+		referenceTables = append(referenceTables, reference.TableName)
+		if helper.ArrayStringContains(crud.ParentTables, reference.TableName) || helper.ArrayStringContains(excludeTables, reference.TableName) {
+			continue
+		}
+		references = append(references, reference)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, childTable := range childTables {
+		if !helper.ArrayStringContains(referenceTables, childTable) {
+			return nil, errors.New(fmt.Sprintf("child-table [%v] has no (single-column foreign-key) reference to the table [%v]", childTable, parentTable))
+		}
+	}
+	return references, nil
+}
+
+// checkSubItems method returns the ChildTables' references with sub-items, i.e. records referencing the records
+// met by the delete-query where-script, in the (delete) transaction
+func (crud *Crud) checkSubItems(tx pgx.Tx, deleteQuery types.DeleteQueryResponseType) ([]types.TableReferenceType, error) {
+	references, err := crud.tableReferences(tx, crud.TableName, crud.ChildTables, []string{crud.TableName})
+	if err != nil {
+		return nil, err
+	}
+	var subItems []types.TableReferenceType
+	for _, reference := range references {
+		var exists bool
+		existsQuery := helper.ComputeSubItemsExistsQuery(reference, deleteQuery.WhereQuery)
+		if err = tx.QueryRow(context.Background(), existsQuery, deleteQuery.FieldValues...).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists {
+			subItems = append(subItems, reference)
+		}
+	}
+	return subItems, nil
+}
+
+// deleteSubItems method deletes the sub-items of the parentTable records met by the parentWhereQuery, depth-first
+// (the sub-items' sub-items first), through the childTables or, below the first level, all referencing tables.
+// The path is the parent-tables' path, excluded from the references, i.e. no cycles.
+func (crud *Crud) deleteSubItems(tx pgx.Tx, parentTable string, parentWhereQuery string, fieldValues []interface{}, childTables []string, path []string) ([]deletedSubItems, error) {
+	if len(path) > maxReferenceDepth {
+		return nil, errors.New(fmt.Sprintf("child-table references exceeded the maximum depth [%v]: %v", maxReferenceDepth, strings.Join(path, " > ")))
+	}
+	references, err := crud.tableReferences(tx, parentTable, childTables, path)
+	if err != nil {
+		return nil, err
+	}
+	returningQuery := ""
+	if crud.LogDelete {
+		returningQuery = " RETURNING *"
+	}
+	var deletedItems []deletedSubItems
+	for _, reference := range references {
+		whereQuery := helper.ComputeSubItemsWhereQuery(reference, parentWhereQuery)
+		referencePath := append(append([]string{}, path...), reference.TableName)
+		subItems, err := crud.deleteSubItems(tx, reference.TableName, whereQuery, fieldValues, nil, referencePath)
+		if err != nil {
+			return nil, err
+		}
+		deletedItems = append(deletedItems, subItems...)
+		deleteQuery := fmt.Sprintf("DELETE FROM %v %v", reference.TableName, whereQuery)
+		deleteCount, deleteRecords, err := execReturning(tx, deleteQuery, fieldValues, returningQuery)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("child-table [%v]: %v", reference.TableName, err.Error()))
+		}
+		if deleteCount > 0 {
+			deletedItems = append(deletedItems, deletedSubItems{
+				tableName:   reference.TableName,
+				recordCount: deleteCount,
+				records:     deleteRecords,
+			})
+		}
+	}
+	return deletedItems, nil
+}
@@ -0,0 +1,130 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: eager-load / include related record(s), by the model relations
+
+package mccrud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mctypes"
+	"github.com/abbeymart/mctypes/ormRelations"
+)
+
+// relationBatchSize is the maximum number of source-values per relation (IN) query
+const relationBatchSize = 1000
+
+// computeIncludeRelations method returns the Model relations for the IncludeRelations (TargetTable) names
+func (crud *Crud) computeIncludeRelations() ([]mctypes.ModelRelationType, error) {
+	if len(crud.IncludeRelations) < 1 {
+		return nil, nil
+	}
+	if crud.Model == nil {
+		return nil, errors.New("the table model is required to include relations")
+	}
+	var relations []mctypes.ModelRelationType
+	for _, relationName := range crud.IncludeRelations {
+		found := false
+		for _, relation := range crud.Model.Relations {
+			if relation.TargetTable != relationName {
+				continue
+			}
+			switch relation.RelationType {
+			case ormRelations.OneToMany, ormRelations.ManyToOne, ormRelations.OneToOne:
+			default:
+				return nil, errors.New(fmt.Sprintf("relation [%v] type [%v] is not supported for include-relations", relationName, relation.RelationType))
+			}
+			relations = append(relations, relation)
+			found = true
+			break
+		}
+		if !found {
+			return nil, errors.New(fmt.Sprintf("relation [%v] is not specified in the table model relations", relationName))
+		}
+	}
+	return relations, nil
+}
+
+// includeRelations method fetches the related (target-table) records, by batched IN-queries, and adds the related
+// records to each of the getResults' records, by the relation name (TargetTable):
+// a list of records for one-to-many, or a record (nil, if not found) for many-to-one and one-to-one relations.
+// The relation SourceField must be included in the (projected) getResults' records.
+func (crud *Crud) includeRelations(getResults []interface{}) error {
+	relations, err := crud.computeIncludeRelations()
+	if err != nil || len(relations) < 1 || len(getResults) < 1 {
+		return err
+	}
+	for _, relation := range relations {
+		// distinct source-field values
+		var sourceValues []interface{}
+		sourceKeys := map[string]bool{}
+		for _, rec := range getResults {
+			recMap, ok := rec.(map[string]interface{})
+			if !ok {
+				return errors.New("invalid record type, for include-relations")
+			}
+			sourceValue, ok := recMap[relation.SourceField]
+			if !ok {
+				return errors.New(fmt.Sprintf("relation [%v] source-field [%v] must be included in the read/projected fields", relation.TargetTable, relation.SourceField))
+			}
+			if sourceValue == nil {
+				continue
+			}
+			sourceKey := fmt.Sprintf("%v", sourceValue)
+			if !sourceKeys[sourceKey] {
+				sourceKeys[sourceKey] = true
+				sourceValues = append(sourceValues, sourceValue)
+			}
+		}
+		// related records, by the target-field value
+		relatedRecs := map[string][]interface{}{}
+		for start := 0; start < len(sourceValues); start += relationBatchSize {
+			end := start + relationBatchSize
+			if end > len(sourceValues) {
+				end = len(sourceValues)
+			}
+			selectQuery, err := helper.ComputeSelectQueryRelation(relation, sourceValues[start:end])
+			if err != nil {
+				return err
+			}
+			rows, qRowErr := crud.AppDb.Query(context.Background(), selectQuery.SelectQuery, selectQuery.FieldValues...)
+			if qRowErr != nil {
+				return errors.New(fmt.Sprintf("relation [%v] db query error: %v", relation.TargetTable, qRowErr.Error()))
+			}
+			for rows.Next() {
+				rec, recErr := rowValues(rows)
+				if recErr != nil {
+					rows.Close()
+					return errors.New(fmt.Sprintf("relation [%v] error reading/getting records: %v", relation.TargetTable, recErr.Error()))
+				}
+				targetKey := fmt.Sprintf("%v", rec[relation.TargetField])
+				relatedRecs[targetKey] = append(relatedRecs[targetKey], rec)
+			}
+			rows.Close()
+			if rowErr := rows.Err(); rowErr != nil {
+				return errors.New(fmt.Sprintf("relation [%v] error reading/getting records: %v", relation.TargetTable, rowErr.Error()))
+			}
+		}
+		// nest the related records
+		for _, rec := range getResults {
+			recMap := rec.(map[string]interface{})
+			related := relatedRecs[fmt.Sprintf("%v", recMap[relation.SourceField])]
+			if recMap[relation.SourceField] == nil {
+				related = nil
+			}
+			if relation.RelationType == ormRelations.OneToMany {
+				if related == nil {
+					related = []interface{}{}
+				}
+				recMap[relation.TargetTable] = related
+			} else if len(related) > 0 {
+				recMap[relation.TargetTable] = related[0]
+			} else {
+				recMap[relation.TargetTable] = nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: RETURNING records, for the create, update and delete operations
+
+package mccrud
+
+import (
+	"context"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/jackc/pgx/v4"
+)
+
+// returningQuery method returns the RETURNING script (ProjectParams fields or all fields), for the ReturnRecords
+// option, or "", if not required
+func (crud *Crud) returningQuery() (string, error) {
+	if !crud.ReturnRecords {
+		return "", nil
+	}
+	return helper.ComputeReturningQuery(crud.ProjectParams)
+}
+
+// scanReturning function scans the RETURNING rows and returns the record-ids and, if returnRecords, the records
+func scanReturning(rows pgx.Rows, returnRecords bool) ([]string, []interface{}, error) {
+	defer rows.Close()
+	var recordIds []string
+	var records []interface{}
+	for rows.Next() {
+		if returnRecords {
+			rec, err := rowValues(rows)
+			if err != nil {
+				return nil, nil, err
+			}
+			recordIds = append(recordIds, fmt.Sprintf("%v", rec["id"]))
+			records = append(records, rec)
+			continue
+		}
+		var recordId string
+		if err := rows.Scan(&recordId); err != nil {
+			return nil, nil, err
+		}
+		recordIds = append(recordIds, recordId)
+	}
+	return recordIds, records, rows.Err()
+}
+
+// dbQueryer is the query interface of the db-pool and transaction
+type dbQueryer interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// execReturning function executes the (update/delete) query, with the returningQuery, if specified, and returns
+// the affected-rows count and the returned records
+func execReturning(db dbQueryer, query string, fieldValues []interface{}, returningQuery string) (int, []interface{}, error) {
+	rows, err := db.Query(context.Background(), query+returningQuery, fieldValues...)
+	if err != nil {
+		return 0, nil, err
+	}
+	if returningQuery == "" {
+		rows.Close()
+		return int(rows.CommandTag().RowsAffected()), nil, rows.Err()
+	}
+	recordIds, records, err := scanReturning(rows, true)
+	return len(recordIds), records, err
+}
@@ -8,16 +8,23 @@ import (
 	"context"
 	"fmt"
 	"github.com/abbeymart/mcauditlog"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mccrud/helper"
 	"github.com/abbeymart/mccrud/types"
 	"github.com/abbeymart/mcresponse"
 	"github.com/abbeymart/mctypes/tasks"
 	"github.com/jackc/pgx/v4"
+	"strings"
 )
 
+// defaultBatchSize is the default number of statements queued per (pgx.Batch) round-trip, see CreateBatch and Update
+const defaultBatchSize = 1000
+
 // Save method creates new record(s) or updates existing record(s)
 func (crud *Crud) Save(tableFields []string) mcresponse.ResponseMessage {
+	// upsert: insert or update (on conflict) the records, new and existing records may be mixed
+	if crud.Upsert {
+		return crud.UpsertRecords(crud.ActionParams, tableFields)
+	}
 	//  determine taskType from actionParams: create or update
 	//  iterate through actionParams: update createRecs, updateRecs & crud.recordIds
 	var (
@@ -84,40 +91,65 @@ func (crud *Crud) Save(tableFields []string) mcresponse.ResponseMessage {
 
 // Create method creates new record(s)
 func (crud *Crud) Create(createRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
+	// perform create/insert action, via transaction/copy-protocol:
+	tx, txRes, ok := crud.beginWrite(createRecs, tableFields, "insertError", "Error creating new record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps
+	createRecs, tableFields, stampErr := crud.stampRecords(tx, createRecs, tableFields, tasks.Create)
+	if stampErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
 	// compute query
 	createQuery, qErr := helper.ComputeCreateQuery(crud.TableName, createRecs, tableFields)
+	returningQuery := ""
+	if qErr == nil {
+		returningQuery, qErr = crud.returningQuery()
+	}
 	if qErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error computing create-query: %v", qErr.Error()),
 			Value:   nil,
 		})
 	}
-	// perform create/insert action, via transaction/copy-protocol:
-	tx, txErr := crud.AppDb.Begin(context.Background())
-	if txErr != nil {
+	if returningQuery != "" {
+		createQuery.CreateQuery = strings.TrimSuffix(createQuery.CreateQuery, " RETURNING id") + returningQuery
+	}
+	// check record(s) exist (ExistParams duplicates)
+	existRecs, existErr := crud.checkRecExist(tx, createRecs, nil, nil)
+	if existErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error creating new record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
 			Value:   nil,
 		})
 	}
-	defer tx.Rollback(context.Background())
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
 
 	// perform records' creation
-	insertCount := 0
-	var insertIds []string
-	var insertId string
-	for _, insertQuery := range createQuery {
-		insertErr := tx.QueryRow(context.Background(), insertQuery).Scan(&insertId)
-		if insertErr != nil {
-			_ = tx.Rollback(context.Background())
-			return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("Error updating record(s): %v", insertErr.Error()),
-				Value:   nil,
-			})
-		}
-		insertCount += 1
-		insertIds = append(insertIds, insertId)
+	rows, insertErr := tx.Query(context.Background(), createQuery.CreateQuery, createQuery.FieldValues[0]...)
+	if insertErr != nil {
+		_ = tx.Rollback(context.Background())
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", insertErr.Error()),
+			Value:   nil,
+		})
+	}
+	insertIds, insertRecords, scanErr := scanReturning(rows, returningQuery != "")
+	if scanErr != nil {
+		_ = tx.Rollback(context.Background())
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", scanErr.Error()),
+			Value:   nil,
+		})
 	}
+	insertCount := len(insertIds)
 	// commit
 	txcErr := tx.Commit(context.Background())
 	if txcErr != nil {
@@ -128,7 +160,7 @@ func (crud *Crud) Create(createRecs types.ActionParamsType, tableFields []string
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	crud.deleteCache()
 
 	// perform audit-log
 	logMessage := ""
@@ -146,8 +178,9 @@ func (crud *Crud) Create(createRecs types.ActionParamsType, tableFields []string
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
 		Value: types.CrudResultType{
-			RecordIds:   insertIds,
-			RecordCount: insertCount,
+			RecordIds:    insertIds,
+			RecordCount:  insertCount,
+			TableRecords: insertRecords,
 		},
 	})
 }
@@ -155,43 +188,92 @@ func (crud *Crud) Create(createRecs types.ActionParamsType, tableFields []string
 // CreateBatch method creates new record(s) by placeholder values from copy-create-query
 // resolve sql-values parsing error: only time.Time and String value requires '' wrapping
 // uuid, json and others (int/bool/float) should not be wrapped as placeholder values
+// The insert statements are queued (pgx.Batch) and sent BatchSize statements per round-trip
 func (crud *Crud) CreateBatch(createRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
+	// perform create/insert action, via transaction/copy-protocol:
+	tx, txRes, ok := crud.beginWrite(createRecs, tableFields, "insertError", "Error creating new record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps
+	createRecs, tableFields, stampErr := crud.stampRecords(tx, createRecs, tableFields, tasks.Create)
+	if stampErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
 	// create from createRecs (actionParams)
 	// compute query
 	createQuery, qErr := helper.ComputeCreateCopyQuery(crud.TableName, createRecs, tableFields)
+	returningQuery := ""
+	if qErr == nil {
+		returningQuery, qErr = crud.returningQuery()
+	}
 	if qErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error computing create-query: %v", qErr.Error()),
 			Value:   nil,
 		})
 	}
-	// perform create/insert action, via transaction/copy-protocol:
-	tx, txErr := crud.AppDb.Begin(context.Background())
-	if txErr != nil {
+	if returningQuery != "" {
+		createQuery.CreateQuery = strings.TrimSuffix(createQuery.CreateQuery, " RETURNING id") + returningQuery
+	}
+	// check record(s) exist (ExistParams duplicates)
+	existRecs, existErr := crud.checkRecExist(tx, createRecs, nil, nil)
+	if existErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error creating new record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
 			Value:   nil,
 		})
 	}
-	defer tx.Rollback(context.Background())
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
 
-	// perform records' creation
+	// perform records' creation, BatchSize records per round-trip
 	insertCount := 0
 	var insertIds []string
-	var insertId string
-	for _, iValues := range createQuery.FieldValues {
-		//fmt.Printf("query: %v\n\n", createQuery.CreateQuery)
-		//fmt.Printf("query-value: %v \n\n", iValues)
-		insertErr := tx.QueryRow(context.Background(), createQuery.CreateQuery, iValues...).Scan(&insertId)
-		if insertErr != nil {
-			_ = tx.Rollback(context.Background())
-			return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("Error updating record(s): %v", insertErr.Error()),
+	var insertRecords []interface{}
+	batchSize := crud.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	for batchStart := 0; batchStart < len(createQuery.FieldValues); batchStart += batchSize {
+		batchEnd := batchStart + batchSize
+		if batchEnd > len(createQuery.FieldValues) {
+			batchEnd = len(createQuery.FieldValues)
+		}
+		batch := &pgx.Batch{}
+		for _, iValues := range createQuery.FieldValues[batchStart:batchEnd] {
+			batch.Queue(createQuery.CreateQuery, iValues...)
+		}
+		batchRes := tx.SendBatch(context.Background(), batch)
+		for recNum := batchStart; recNum < batchEnd; recNum++ {
+			var recIds []string
+			var recRecords []interface{}
+			rows, insertErr := batchRes.Query()
+			if insertErr == nil {
+				recIds, recRecords, insertErr = scanReturning(rows, returningQuery != "")
+			}
+			if insertErr != nil {
+				_ = batchRes.Close()
+				return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("Error creating record #%v: %v", recNum, insertErr.Error()),
+					Value:   nil,
+				})
+			}
+			insertCount += len(recIds)
+			insertIds = append(insertIds, recIds...)
+			insertRecords = append(insertRecords, recRecords...)
+		}
+		if bErr := batchRes.Close(); bErr != nil {
+			return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error creating new record(s): %v", bErr.Error()),
 				Value:   nil,
 			})
 		}
-		insertCount += 1
-		insertIds = append(insertIds, insertId)
 	}
 	// commit
 	txcErr := tx.Commit(context.Background())
@@ -203,7 +285,7 @@ func (crud *Crud) CreateBatch(createRecs types.ActionParamsType, tableFields []s
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	crud.deleteCache()
 
 	// perform audit-log
 	logMessage := ""
@@ -221,55 +303,394 @@ func (crud *Crud) CreateBatch(createRecs types.ActionParamsType, tableFields []s
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
 		Value: types.CrudResultType{
-			RecordIds:   insertIds,
-			RecordCount: insertCount,
+			RecordIds:    insertIds,
+			RecordCount:  insertCount,
+			TableRecords: insertRecords,
 		},
 	})
 }
 
-// CreateCopy method creates new record(s) using Pg CopyFrom
-// TODO: resolve sql-values parsing error (incorrect binary data format (SQLSTATE 22P03) - ?uuid primary key?)
+// CreateCopy method creates new record(s) using Pg CopyFrom, see CreateCopyFrom
 func (crud *Crud) CreateCopy(createRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
-	// create from createRecs (actionParams)
-	// compute query
-	createQuery, qErr := helper.ComputeCreateCopyQuery(crud.TableName, createRecs, tableFields)
+	if len(createRecs) < 1 {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: "action-params are required for the create operation",
+			Value:   nil,
+		})
+	}
+	return crud.createCopy(helper.NewRecordSource(createRecs), helper.ComputeCreateFields(createRecs, tableFields), createRecs)
+}
+
+// CreateCopyFrom method creates new record(s), streamed from the record-source (e.g. a file or query reader), using
+// Pg CopyFrom into a temp-table, with the values encoded by the table column-types (uuid, json/jsonb, timestamp...),
+// then insert-select into the table, returning the generated ids, in a transaction. The tableFields are required.
+func (crud *Crud) CreateCopyFrom(source types.RecordSourceType, tableFields []string) mcresponse.ResponseMessage {
+	return crud.createCopy(source, tableFields, nil)
+}
+
+// createCopy method creates new record(s) from the record-source, see CreateCopyFrom. The createRecs, i.e. the records
+// of the record-source, if specified, are validated (see beginWrite), and audit-logged, in place of the inserted
+// record-ids.
+func (crud *Crud) createCopy(source types.RecordSourceType, tableFields []string, createRecs types.ActionParamsType) mcresponse.ResponseMessage {
+	// compute copy temp-table and insert-select queries
+	tempTableName := fmt.Sprintf("tmp_%v_copy", crud.TableName)
+	tempQuery, qErr := helper.CreateTempTableAsQuery(crud.TableName, tempTableName, tableFields)
 	if qErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error computing create-query: %v", qErr.Error()),
 			Value:   nil,
 		})
 	}
-	//fmt.Printf("create-query: %v \n", createQuery)
-	//fmt.Printf("create-query-fields: %v \n", createQuery.FieldNames)
-	//fmt.Printf("create-query-values: %v \n\n", createQuery.FieldValues)
-	// perform create/insert action, via transaction/copy-protocol:
-	tx, txErr := crud.AppDb.Begin(context.Background())
-	if txErr != nil {
+	// perform create/insert action, via transaction/temp-table/copy-protocol:
+	tx, txRes, ok := crud.beginWrite(createRecs, tableFields, "insertError", "Error creating new record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps, of the insert-select records
+	stamps, stampErr := crud.createStamps(tx, tableFields)
+	if stampErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error creating new record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
+	insertQuery, insertValues, qErr := helper.ComputeTempTableInsertQuery(crud.TableName, tempTableName, tableFields, stamps, "")
+	if qErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing create-query: %v", qErr.Error()),
 			Value:   nil,
 		})
 	}
-	defer tx.Rollback(context.Background())
 
+	copySource, sErr := helper.ComputeCopySource(tx, crud.TableName, source, tableFields)
+	if sErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing the copy-source: %v", sErr.Error()),
+			Value:   nil,
+		})
+	}
+	if _, tErr := tx.Exec(context.Background(), tempQuery); tErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating the copy temp-table: %v", tErr.Error()),
+			Value:   nil,
+		})
+	}
 	// bulk create
-	copyCount, cErr := tx.CopyFrom(
-		context.Background(),
-		pgx.Identifier{crud.TableName},
-		createQuery.FieldNames,
-		pgx.CopyFromRows(createQuery.FieldValues),
-	)
-	if cErr != nil {
-		_ = tx.Rollback(context.Background())
+	if _, cErr := tx.CopyFrom(context.Background(), pgx.Identifier{tempTableName}, tableFields, copySource); cErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error creating new record(s): %v", cErr.Error()),
 			Value:   nil,
 		})
 	}
+	// check record(s) exist (ExistParams duplicates)
+	existRecs, existErr := crud.checkTempTableRecExist(tx, tempTableName, tableFields, nil)
+	if existErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
+			Value:   nil,
+		})
+	}
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
+	// insert-select from the copy temp-table
+	var insertIds []string
+	rows, insertErr := tx.Query(context.Background(), insertQuery, insertValues...)
+	if insertErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", insertErr.Error()),
+			Value:   nil,
+		})
+	}
+	for rows.Next() {
+		var insertId string
+		if scanErr := rows.Scan(&insertId); scanErr != nil {
+			rows.Close()
+			return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error creating new record(s): %v", scanErr.Error()),
+				Value:   nil,
+			})
+		}
+		insertIds = append(insertIds, insertId)
+	}
+	rows.Close()
+	if rowErr := rows.Err(); rowErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", rowErr.Error()),
+			Value:   nil,
+		})
+	}
+	// commit (drops the copy temp-table)
+	if txcErr := tx.Commit(context.Background()); txcErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	// delete cache
+	crud.deleteCache()
+
+	// perform audit-log
+	logMessage := ""
+	if crud.LogCreate {
+		var logRecords interface{} = insertIds
+		if len(createRecs) > 0 {
+			logRecords = createRecs
+		}
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName:  crud.TableName,
+			LogRecords: logRecords,
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Create, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: logMessage,
+		Value: types.CrudResultType{
+			RecordIds:   insertIds,
+			RecordCount: len(insertIds),
+		},
+	})
+}
+
+// UpsertRecords method inserts new record(s) or, on conflict (ConflictFields, default: id), updates (UpsertFields)
+// or skips (UpsertDoNothing) the existing record(s), in a transaction.
+// UpsertResults contains the result (inserted, updated or skipped) for each of the upsertRecs.
+func (crud *Crud) UpsertRecords(upsertRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
+	// perform upsert action, via transaction:
+	tx, txRes, ok := crud.beginWrite(upsertRecs, tableFields, "saveError", "Error saving record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps: create stamps, the created_* fields are not updated on conflict
+	upsertRecs, tableFields, stampErr := crud.stampRecords(tx, upsertRecs, tableFields, tasks.Create)
+	if stampErr != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
+	// compute query
+	upsertQuery, qErr := helper.ComputeUpsertQuery(crud.TableName, upsertRecs, tableFields, crud.ConflictFields, crud.UpsertFields, crud.UpsertDoNothing)
+	if qErr != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing upsert-query: %v", qErr.Error()),
+			Value:   nil,
+		})
+	}
+	// check record(s) exist (ExistParams duplicates), excluding the on-conflict (update) target record(s)
+	conflictFields := crud.ConflictFields
+	if len(conflictFields) < 1 {
+		conflictFields = []string{"id"}
+	}
+	existRecs, existErr := crud.checkRecExist(tx, upsertRecs, nil, conflictFields)
+	if existErr != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
+			Value:   nil,
+		})
+	}
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
+
+	var recordIds []string
+	var upsertResults []types.UpsertResultType
+	var insertRecs, updateRecs types.ActionParamsType
+	var updateIds []string
+	for recNum, recQuery := range upsertQuery {
+		var recordId string
+		var inserted bool
+		upsertErr := tx.QueryRow(context.Background(), recQuery.UpsertQuery, recQuery.FieldValues...).Scan(&recordId, &inserted)
+		if upsertErr == pgx.ErrNoRows {
+			// on conflict do nothing
+			upsertResults = append(upsertResults, types.UpsertResultType{RecordIndex: recNum, Action: types.UpsertSkipped})
+			continue
+		}
+		if upsertErr != nil {
+			return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error saving record #%v: %v", recNum, upsertErr.Error()),
+				Value:   nil,
+			})
+		}
+		action := types.UpsertUpdated
+		if inserted {
+			action = types.UpsertInserted
+			insertRecs = append(insertRecs, upsertRecs[recNum])
+		} else {
+			updateRecs = append(updateRecs, upsertRecs[recNum])
+			updateIds = append(updateIds, recordId)
+		}
+		recordIds = append(recordIds, recordId)
+		upsertResults = append(upsertResults, types.UpsertResultType{RecordIndex: recNum, RecordId: recordId, Action: action})
+	}
 	// commit
-	txcErr := tx.Commit(context.Background())
-	if txcErr != nil {
-		_ = tx.Rollback(context.Background())
+	if txcErr := tx.Commit(context.Background()); txcErr != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error saving record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	// delete cache
+	crud.deleteCache()
+
+	// perform audit-log: inserted (create) and updated (update) records
+	logMessage := ""
+	if crud.LogCreate && len(insertRecs) > 0 {
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName:  crud.TableName,
+			LogRecords: insertRecs,
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Create, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+	if crud.LogUpdate && len(updateRecs) > 0 {
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName:     crud.TableName,
+			LogRecords:    updateIds,
+			NewLogRecords: updateRecs,
+		}
+		if logMessage != "" {
+			logMessage += " | "
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Update, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage += fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage += fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: logMessage,
+		Value: types.CrudResultType{
+			RecordIds:     recordIds,
+			RecordCount:   len(recordIds),
+			UpsertResults: upsertResults,
+		},
+	})
+}
+
+// CreateStaged method creates new record(s) via a staging temp-table (from the Model or, if not specified, the table):
+// bulk-load (copy) the records into the temp-table, then insert-select into the table, with the optional
+// on-conflict (ConflictFields) update (UpdateFields) or do-nothing, in a transaction
+func (crud *Crud) CreateStaged(createRecs types.ActionParamsType, params types.StageParamsType) mcresponse.ResponseMessage {
+	if len(createRecs) < 1 {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: "action-params are required for the create operation",
+			Value:   nil,
+		})
+	}
+	// compute copy fields
+	tableFields := helper.ComputeCreateFields(createRecs, params.TableFields)
+	var qErr error
+	tempTableName := fmt.Sprintf("tmp_%v_stage", crud.TableName)
+	var tempQuery string
+	if crud.Model != nil {
+		tempQuery, qErr = helper.CreateTempTableQuery(*crud.Model, tempTableName)
+	} else {
+		tempQuery, qErr = helper.CreateTempTableLikeQuery(crud.TableName, tempTableName)
+	}
+	onConflictQuery := ""
+	if qErr == nil && len(params.ConflictFields) > 0 {
+		onConflictQuery, qErr = helper.ComputeOnConflictQuery(params.ConflictFields, params.UpdateFields, len(params.UpdateFields) < 1)
+	}
+	if qErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing staged create-query: %v", qErr.Error()),
+			Value:   nil,
+		})
+	}
+	// perform create/insert action, via transaction/temp-table/copy-protocol:
+	tx, txRes, ok := crud.beginWrite(createRecs, tableFields, "insertError", "Error creating new record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps, of the insert-select records
+	stamps, stampErr := crud.createStamps(tx, tableFields)
+	if stampErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
+	insertQuery, insertValues, qErr := helper.ComputeTempTableInsertQuery(crud.TableName, tempTableName, tableFields, stamps, onConflictQuery)
+	if qErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing staged create-query: %v", qErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	if _, tErr := tx.Exec(context.Background(), tempQuery); tErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating the staging temp-table: %v", tErr.Error()),
+			Value:   nil,
+		})
+	}
+	// bulk-load the staging temp-table
+	copySource, sErr := helper.ComputeCopySource(tx, crud.TableName, helper.NewRecordSource(createRecs), tableFields)
+	if sErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing the copy-source: %v", sErr.Error()),
+			Value:   nil,
+		})
+	}
+	if _, cErr := tx.CopyFrom(context.Background(), pgx.Identifier{tempTableName}, tableFields, copySource); cErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error loading the staging temp-table: %v", cErr.Error()),
+			Value:   nil,
+		})
+	}
+	// check record(s) exist (ExistParams duplicates), excluding the on-conflict target record(s)
+	existRecs, existErr := crud.checkTempTableRecExist(tx, tempTableName, tableFields, params.ConflictFields)
+	if existErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
+			Value:   nil,
+		})
+	}
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
+	// insert-select from the staging temp-table
+	var insertIds []string
+	rows, insertErr := tx.Query(context.Background(), insertQuery, insertValues...)
+	if insertErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", insertErr.Error()),
+			Value:   nil,
+		})
+	}
+	for rows.Next() {
+		var insertId string
+		if scanErr := rows.Scan(&insertId); scanErr != nil {
+			rows.Close()
+			return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error creating new record(s): %v", scanErr.Error()),
+				Value:   nil,
+			})
+		}
+		insertIds = append(insertIds, insertId)
+	}
+	rows.Close()
+	if rowErr := rows.Err(); rowErr != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", rowErr.Error()),
+			Value:   nil,
+		})
+	}
+	// commit (drops the staging temp-table)
+	if txcErr := tx.Commit(context.Background()); txcErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error creating new record(s): %v", txcErr.Error()),
 			Value:   nil,
@@ -277,14 +698,14 @@ func (crud *Crud) CreateCopy(createRecs types.ActionParamsType, tableFields []st
 	}
 
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	crud.deleteCache()
 
 	// perform audit-log
 	logMessage := ""
 	if crud.LogCreate {
 		auditInfo := mcauditlog.PgxAuditLogOptionsType{
 			TableName:  crud.TableName,
-			LogRecords: crud.ActionParams,
+			LogRecords: createRecs,
 		}
 		if logRes, logErr := crud.TransLog.AuditLog(tasks.Create, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -295,44 +716,122 @@ func (crud *Crud) CreateCopy(createRecs types.ActionParamsType, tableFields []st
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
 		Value: types.CrudResultType{
-			RecordIds:   crud.RecordIds,
-			RecordCount: int(copyCount),
+			RecordIds:   insertIds,
+			RecordCount: len(insertIds),
 		},
 	})
 }
 
-// Update method updates existing record(s)
+// Update method updates existing record(s), queued (pgx.Batch) and sent BatchSize statements per round-trip.
+// The soft-deleted records are not updated, for the SoftDelete option.
 func (crud *Crud) Update(updateRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
+	// perform update action, via transaction:
+	tx, txRes, ok := crud.beginWrite(updateRecs, tableFields, "updateError", "Error updating record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps
+	updateRecs, tableFields, stampErr := crud.stampRecords(tx, updateRecs, tableFields, tasks.Update)
+	if stampErr != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
 	// create from updatedRecs (actionParams)
-	updateQuery, err := helper.ComputeUpdateQuery(crud.TableName, updateRecs, tableFields)
+	updateQuery, err := helper.ComputeUpdateQuery(crud.TableName, updateRecs, tableFields, crud.VersionField)
+	if err == nil && crud.SoftDelete {
+		// the soft-deleted records are not updated
+		for recNum := range updateQuery {
+			updateQuery[recNum] = helper.ComputeNotDeletedUpdateQuery(updateQuery[recNum])
+		}
+	}
+	returningQuery := ""
+	if err == nil {
+		returningQuery, err = crud.returningQuery()
+	}
 	if err != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error computing update-query: %v", err.Error()),
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin(context.Background())
-	if txErr != nil {
+	// check record(s) exist (ExistParams duplicates)
+	existRecs, existErr := crud.checkRecExist(tx, updateRecs, nil, nil)
+	if existErr != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
 			Value:   nil,
 		})
 	}
-	defer tx.Rollback(context.Background())
-	// perform records' updates
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
+	// perform records' updates, BatchSize records per round-trip
 	updateCount := 0
-	//fmt.Printf("update-queries: %v\n", updateQuery)
-	for _, upQuery := range updateQuery {
-		commandTag, updateErr := tx.Exec(context.Background(), upQuery)
-		if updateErr != nil {
-			_ = tx.Rollback(context.Background())
+	var staleIds []string
+	var updateRecords []interface{}
+	batchSize := crud.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	for batchStart := 0; batchStart < len(updateQuery); batchStart += batchSize {
+		batchEnd := batchStart + batchSize
+		if batchEnd > len(updateQuery) {
+			batchEnd = len(updateQuery)
+		}
+		batch := &pgx.Batch{}
+		for _, upQuery := range updateQuery[batchStart:batchEnd] {
+			batch.Queue(upQuery.UpdateQuery+returningQuery, upQuery.FieldValues...)
+		}
+		batchRes := tx.SendBatch(context.Background(), batch)
+		for recNum := batchStart; recNum < batchEnd; recNum++ {
+			rowsAffected := 0
+			var updateErr error
+			if returningQuery != "" {
+				var rows pgx.Rows
+				if rows, updateErr = batchRes.Query(); updateErr == nil {
+					var recIds []string
+					var recRecords []interface{}
+					recIds, recRecords, updateErr = scanReturning(rows, true)
+					rowsAffected = len(recIds)
+					updateRecords = append(updateRecords, recRecords...)
+				}
+			} else {
+				commandTag, execErr := batchRes.Exec()
+				rowsAffected, updateErr = int(commandTag.RowsAffected()), execErr
+			}
+			if updateErr != nil {
+				_ = batchRes.Close()
+				return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("Error updating record #%v: %v", recNum, updateErr.Error()),
+					Value:   nil,
+				})
+			}
+			// optimistic concurrency control: stale (or deleted) record
+			if crud.VersionField != "" && rowsAffected == 0 {
+				staleIds = append(staleIds, fmt.Sprintf("%v", updateRecs[recNum]["id"]))
+			}
+			updateCount += rowsAffected
+		}
+		if bErr := batchRes.Close(); bErr != nil {
 			return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("Error updating record(s): %v", updateErr.Error()),
+				Message: fmt.Sprintf("Error updating record(s): %v", bErr.Error()),
 				Value:   nil,
 			})
 		}
-		updateCount += int(commandTag.RowsAffected())
+	}
+	// stale record(s): rollback the update(s)
+	if len(staleIds) > 0 {
+		res := mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Update conflict: record(s) [%v] changed or removed since read (%v)", strings.Join(staleIds, ", "), crud.VersionField),
+			Value:   staleIds,
+		})
+		res.Code = "conflict"
+		res.ResCode = mcresponse.Conflict
+		res.ResMessage = mcresponse.StatusText[mcresponse.Conflict]
+		return res
 	}
 	// commit
 	txcErr := tx.Commit(context.Background())
@@ -345,38 +844,67 @@ func (crud *Crud) Update(updateRecs types.ActionParamsType, tableFields []string
 	}
 
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	crud.deleteCache()
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: "Record(s) update completed successfully",
 		Value: types.CrudResultType{
-			QueryParam:  crud.QueryParams,
-			RecordIds:   crud.RecordIds,
-			RecordCount: updateCount,
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  updateCount,
+			TableRecords: updateRecords,
 		},
 	})
 }
 
-// UpdateById method updates existing records (in batch) that met the specified record-id(s)
+// UpdateById method updates existing records (in batch) that met the specified record-id(s), except the soft-deleted
+// records, for the SoftDelete option
 func (crud *Crud) UpdateById(updateRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
+	// perform update action, via transaction:
+	tx, txRes, ok := crud.beginWrite(updateRecs, tableFields, "updateError", "Error updating record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps
+	updateRecs, tableFields, stampErr := crud.stampRecords(tx, updateRecs, tableFields, tasks.Update)
+	if stampErr != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
+			Value:   nil,
+		})
+	}
 	// create from updatedRecs (actionParams)
-	updateQuery, err := helper.ComputeUpdateQueryById(crud.TableName, updateRecs, crud.RecordIds, tableFields)
+	var updateQuery types.UpdateQueryResponseType
+	var err error
+	if crud.SoftDelete {
+		// the record-ids, composed with the not-deleted condition
+		updateQuery, err = helper.ComputeUpdateQueryByParam(crud.TableName, updateRecs, crud.updateQueryParams(crud.computeQueryParams()), tableFields)
+	} else {
+		updateQuery, err = helper.ComputeUpdateQueryById(crud.TableName, updateRecs, crud.RecordIds, tableFields)
+	}
+	returningQuery := ""
+	if err == nil {
+		returningQuery, err = crud.returningQuery()
+	}
 	if err != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error computing update-query: %v", err.Error()),
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin(context.Background())
-	if txErr != nil {
+	// check record(s) exist (ExistParams duplicates)
+	existRecs, existErr := crud.checkRecExistByIds(tx, updateRecs[0], crud.RecordIds)
+	if existErr != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
 			Value:   nil,
 		})
 	}
-	defer tx.Rollback(context.Background())
-	commandTag, updateErr := tx.Exec(context.Background(), updateQuery)
+	if len(existRecs) > 0 {
+		return crud.recExistResponse(existRecs)
+	}
+	updateCount, updateRecords, updateErr := execReturning(tx, updateQuery.UpdateQuery, updateQuery.FieldValues, returningQuery)
 	if updateErr != nil {
 		_ = tx.Rollback(context.Background())
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
@@ -395,38 +923,66 @@ func (crud *Crud) UpdateById(updateRecs types.ActionParamsType, tableFields []st
 	}
 
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	crud.deleteCache()
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: "Record(s) update completed successfully",
 		Value: types.CrudResultType{
-			QueryParam:  crud.QueryParams,
-			RecordIds:   crud.RecordIds,
-			RecordCount: int(commandTag.RowsAffected()),
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  updateCount,
+			TableRecords: updateRecords,
 		},
 	})
 }
 
-// UpdateByParam method updates existing records (in batch) that met the specified query-params or where conditions
+// UpdateByParam method updates existing records (in batch) that met the specified query-params or where conditions,
+// except the soft-deleted records, for the SoftDelete option
 func (crud *Crud) UpdateByParam(updateRecs types.ActionParamsType, tableFields []string) mcresponse.ResponseMessage {
-	// create from updatedRecs (actionParams)
-	updateQuery, err := helper.ComputeUpdateQueryByParam(crud.TableName, updateRecs, crud.QueryParams, tableFields)
-	if err != nil {
+	// perform update action, via transaction:
+	tx, txRes, ok := crud.beginWrite(updateRecs, tableFields, "updateError", "Error updating record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	// actor, time and active stamps
+	updateRecs, tableFields, stampErr := crud.stampRecords(tx, updateRecs, tableFields, tasks.Update)
+	if stampErr != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error computing update-query: %v", err.Error()),
+			Message: fmt.Sprintf("Error stamping record(s): %v", stampErr.Error()),
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin(context.Background())
-	if txErr != nil {
+	// create from updatedRecs (actionParams)
+	updateQuery, err := helper.ComputeUpdateQueryByParam(crud.TableName, updateRecs, crud.updateQueryParams(crud.QueryParams), tableFields)
+	returningQuery := ""
+	if err == nil {
+		returningQuery, err = crud.returningQuery()
+	}
+	if err != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error computing update-query: %v", err.Error()),
 			Value:   nil,
 		})
 	}
-	defer tx.Rollback(context.Background())
-	commandTag, updateErr := tx.Exec(context.Background(), updateQuery)
+	// check record(s) exist (ExistParams duplicates), excluding the records to update
+	if len(crud.ExistParams) > 0 {
+		recordIds, idsErr := crud.queryParamsIds(tx)
+		existRecs, existErr := []types.RecExistType{}, idsErr
+		if idsErr == nil {
+			existRecs, existErr = crud.checkRecExistByIds(tx, updateRecs[0], recordIds)
+		}
+		if existErr != nil {
+			return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error checking record(s) exist: %v", existErr.Error()),
+				Value:   nil,
+			})
+		}
+		if len(existRecs) > 0 {
+			return crud.recExistResponse(existRecs)
+		}
+	}
+	updateCount, updateRecords, updateErr := execReturning(tx, updateQuery.UpdateQuery, updateQuery.FieldValues, returningQuery)
 	if updateErr != nil {
 		_ = tx.Rollback(context.Background())
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
@@ -445,36 +1001,46 @@ func (crud *Crud) UpdateByParam(updateRecs types.ActionParamsType, tableFields [
 	}
 
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.HashKey, "hash")
+	crud.deleteCache()
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: "Record(s) update completed successfully",
 		Value: types.CrudResultType{
-			QueryParam:  crud.QueryParams,
-			RecordIds:   crud.RecordIds,
-			RecordCount: int(commandTag.RowsAffected()),
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  updateCount,
+			TableRecords: updateRecords,
 		},
 	})
 }
 
 func (crud *Crud) UpdateLog(updateRecs types.ActionParamsType, tableFields []string, upTableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// get records to update, for audit-log
+	// get records to update, for audit-log (uncached, i.e. the current records)
 	if crud.LogUpdate && len(tableFields) == len(tableFieldPointers) {
-		getRes := crud.GetById(tableFields, tableFieldPointers)
+		getRes := crud.getById(tableFields, tableFieldPointers)
 		value, _ := getRes.Value.(types.CrudResultType)
 		crud.CurrentRecords = value.TableRecords
 	}
 
 	// perform update
 	updateRes := crud.Update(updateRecs, upTableFields)
+	// rejected (recExist), conflicted or failed update: no audit-log
+	if updateRes.Code != "success" {
+		return updateRes
+	}
 
 	// perform audit-log
 	logMessage := ""
 	if crud.LogUpdate {
+		var newLogRecords interface{} = crud.ActionParams
+		// updated records, returned (ReturnRecords) by the update
+		if updateValue, ok := updateRes.Value.(types.CrudResultType); ok && len(updateValue.TableRecords) > 0 {
+			newLogRecords = updateValue.TableRecords
+		}
 		auditInfo := mcauditlog.PgxAuditLogOptionsType{
 			TableName:     crud.TableName,
 			LogRecords:    crud.CurrentRecords,
-			NewLogRecords: crud.ActionParams,
+			NewLogRecords: newLogRecords,
 		}
 		if logRes, logErr := crud.TransLog.AuditLog(tasks.Update, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -483,31 +1049,38 @@ func (crud *Crud) UpdateLog(updateRecs types.ActionParamsType, tableFields []str
 		}
 	}
 
-	// overall response
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: updateRes.Message + " | " + logMessage,
-		Value:   updateRes.Value,
-	})
+	// overall response, i.e. the update response, with the audit-log message
+	updateRes.Message = updateRes.Message + " | " + logMessage
+	return updateRes
 }
 
 func (crud *Crud) UpdateByIdLog(updateRecs types.ActionParamsType, tableFields []string, upTableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// get records to update, for audit-log
+	// get records to update, for audit-log (uncached, i.e. the current records)
 	if crud.LogUpdate && len(tableFields) == len(tableFieldPointers) {
-		getRes := crud.GetById(tableFields, tableFieldPointers)
+		getRes := crud.getById(tableFields, tableFieldPointers)
 		value, _ := getRes.Value.(types.CrudResultType)
 		crud.CurrentRecords = value.TableRecords
 	}
 
 	// perform update-by-id
 	updateRes := crud.UpdateById(updateRecs, upTableFields)
+	// rejected (recExist), conflicted or failed update: no audit-log
+	if updateRes.Code != "success" {
+		return updateRes
+	}
 
 	// perform audit-log
 	logMessage := ""
 	if crud.LogUpdate {
+		var newLogRecords interface{} = crud.ActionParams
+		// updated records, returned (ReturnRecords) by the update
+		if updateValue, ok := updateRes.Value.(types.CrudResultType); ok && len(updateValue.TableRecords) > 0 {
+			newLogRecords = updateValue.TableRecords
+		}
 		auditInfo := mcauditlog.PgxAuditLogOptionsType{
 			TableName:     crud.TableName,
 			LogRecords:    crud.CurrentRecords,
-			NewLogRecords: crud.ActionParams,
+			NewLogRecords: newLogRecords,
 		}
 		if logRes, logErr := crud.TransLog.AuditLog(tasks.Update, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -516,31 +1089,38 @@ func (crud *Crud) UpdateByIdLog(updateRecs types.ActionParamsType, tableFields [
 		}
 	}
 
-	// overall response
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: updateRes.Message + " | " + logMessage,
-		Value:   updateRes.Value,
-	})
+	// overall response, i.e. the update response, with the audit-log message
+	updateRes.Message = updateRes.Message + " | " + logMessage
+	return updateRes
 }
 
 func (crud *Crud) UpdateByParamLog(updateRecs types.ActionParamsType, tableFields []string, upTableFields []string, tableFieldPointers []interface{}) mcresponse.ResponseMessage {
-	// get records to update, for audit-log
+	// get records to update, for audit-log (uncached, i.e. the current records)
 	if crud.LogUpdate && len(tableFields) == len(tableFieldPointers) {
-		getRes := crud.GetByParam(tableFields, tableFieldPointers)
+		getRes := crud.getByParam(tableFields, tableFieldPointers)
 		value, _ := getRes.Value.(types.CrudResultType)
 		crud.CurrentRecords = value.TableRecords
 	}
 
 	// perform update-by-id
 	updateRes := crud.UpdateByParam(updateRecs, upTableFields)
+	// rejected (recExist), conflicted or failed update: no audit-log
+	if updateRes.Code != "success" {
+		return updateRes
+	}
 
 	// perform audit-log
 	logMessage := ""
 	if crud.LogUpdate {
+		var newLogRecords interface{} = crud.ActionParams
+		// updated records, returned (ReturnRecords) by the update
+		if updateValue, ok := updateRes.Value.(types.CrudResultType); ok && len(updateValue.TableRecords) > 0 {
+			newLogRecords = updateValue.TableRecords
+		}
 		auditInfo := mcauditlog.PgxAuditLogOptionsType{
 			TableName:     crud.TableName,
 			LogRecords:    crud.CurrentRecords,
-			NewLogRecords: crud.ActionParams,
+			NewLogRecords: newLogRecords,
 		}
 		if logRes, logErr := crud.TransLog.AuditLog(tasks.Update, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -549,9 +1129,7 @@ func (crud *Crud) UpdateByParamLog(updateRecs types.ActionParamsType, tableField
 		}
 	}
 
-	// overall response
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: updateRes.Message + " | " + logMessage,
-		Value:   updateRes.Value,
-	})
+	// overall response, i.e. the update response, with the audit-log message
+	updateRes.Message = updateRes.Message + " | " + logMessage
+	return updateRes
 }
@@ -108,6 +108,84 @@ func TestSave(t *testing.T) {
 		},
 	})
 
+	mctest.McTest(mctest.OptionValue{
+		Name: "should create two new records via a staging temp-table and return success:",
+		TestFunc: func() {
+			crud, ok := crud.(*Crud)
+			if !ok {
+				mctest.AssertEquals(t, ok, true, "crud should be instance of mccrud.Crud")
+			}
+			res := crud.CreateStaged(CreateActionParams, types.StageParamsType{})
+			fmt.Println(res.Message, res.ResCode)
+			value, _ := res.Value.(types.CrudResultType)
+			mctest.AssertEquals(t, res.Code, "success", "staged-create should return code: success")
+			mctest.AssertEquals(t, value.RecordCount, 2, "staged-create-count should be: 2")
+			mctest.AssertEquals(t, len(value.RecordIds), 2, "staged-create-recordIds-length should be: 2")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should create two new records and return the created records:",
+		TestFunc: func() {
+			returnOptions := TestCrudParamOptions
+			returnOptions.ReturnRecords = true
+			returnCrud := NewCrud(createCrudParams, returnOptions)
+			res := returnCrud.Create(CreateActionParams, []string{})
+			fmt.Println(res.Message, res.ResCode)
+			value, _ := res.Value.(types.CrudResultType)
+			mctest.AssertEquals(t, res.Code, "success", "create should return code: success")
+			mctest.AssertEquals(t, len(value.RecordIds), 2, "create-recordIds-length should be: 2")
+			mctest.AssertEquals(t, len(value.TableRecords), 2, "create-tableRecords-length should be: 2")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should create two new records via copy and return the new record-ids:",
+		TestFunc: func() {
+			crud, ok := crud.(*Crud)
+			if !ok {
+				mctest.AssertEquals(t, ok, true, "crud should be instance of mccrud.Crud")
+			}
+			res := crud.CreateCopy(CreateActionParams, []string{})
+			fmt.Println(res.Message, res.ResCode)
+			value, _ := res.Value.(types.CrudResultType)
+			mctest.AssertEquals(t, res.Code, "success", "copy-create should return code: success")
+			mctest.AssertEquals(t, value.RecordCount, 2, "copy-create-count should be: 2")
+			mctest.AssertEquals(t, len(value.RecordIds), 2, "copy-create-recordIds-length should be: 2")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return recExist for duplicate records by the exist-params:",
+		TestFunc: func() {
+			existParams := createCrudParams
+			existParams.ExistParams = types.ExistParamsType{
+				{"table_name": nil, "log_type": nil},
+			}
+			existCrud := NewCrud(existParams, TestCrudParamOptions)
+			res := existCrud.Save([]string{})
+			fmt.Println(res.Message, res.ResCode)
+			value, _ := res.Value.([]types.RecExistType)
+			mctest.AssertEquals(t, res.Code, "recExist", "save-create should return code: recExist")
+			mctest.AssertEquals(t, len(value) > 0, true, "recExist-records-length should be: > 0")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should upsert (insert and update) two records and return success:",
+		TestFunc: func() {
+			crud, ok := crud.(*Crud)
+			if !ok {
+				mctest.AssertEquals(t, ok, true, "crud should be instance of mccrud.Crud")
+			}
+			res := crud.UpsertRecords(types.ActionParamsType{valParam1, updateRec1}, []string{})
+			fmt.Println(res.Message, res.ResCode)
+			value, _ := res.Value.(types.CrudResultType)
+			mctest.AssertEquals(t, res.Code, "success", "upsert should return code: success")
+			mctest.AssertEquals(t, len(value.UpsertResults), 2, "upsert-results-length should be: 2")
+		},
+	})
+
 	mctest.McTest(mctest.OptionValue{
 		Name: "should update two records and return success:",
 		TestFunc: func() {
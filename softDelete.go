@@ -0,0 +1,224 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: restore and purge soft-deleted record(s), and exclude soft-deleted record(s) from the read-queries
+
+package mccrud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcauditlog"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctypes/tasks"
+)
+
+// readQueryParams method returns the read-query where-params, i.e. the where-params composed with the not-deleted
+// condition (see helper.ComputeNotDeletedParams), for the SoftDelete option, unless IncludeDeleted. The where-params
+// are returned as-is, otherwise. The where-params, the sort/project params and the (select) tableFields are validated
+// against the model (see validateParams), i.e. the read pre-flight of the read methods.
+func (crud *Crud) readQueryParams(where types.QueryParamType, tableFields []string) (types.QueryParamType, error) {
+	params := crud.CrudParamsType
+	params.QueryParams = where
+	params.ActionParams = nil
+	if err := crud.validateParams(params, tableFields); err != nil {
+		return nil, err
+	}
+	if !crud.SoftDelete || crud.IncludeDeleted {
+		return where, nil
+	}
+	return helper.ComputeNotDeletedParams(where), nil
+}
+
+// updateQueryParams method returns the update-query where-params, i.e. the where-params composed with the not-deleted
+// condition (see helper.ComputeNotDeletedParams), for the SoftDelete option, i.e. the soft-deleted records are not
+// updated. The where-params are returned as-is, otherwise.
+func (crud *Crud) updateQueryParams(where types.QueryParamType) types.QueryParamType {
+	if !crud.SoftDelete {
+		return where
+	}
+	return helper.ComputeNotDeletedParams(where)
+}
+
+// computeSelectQuery method computes the select-query for the read-query where-params (see readQueryParams) of the
+// where-params, or all records, if not specified. The search_rank field is computed from the search where-conditions.
+func (crud *Crud) computeSelectQuery(where types.QueryParamType, tableFields []string) (types.SelectQueryResponseType, error) {
+	where, err := crud.readQueryParams(where, tableFields)
+	if err != nil {
+		return types.SelectQueryResponseType{}, err
+	}
+	if len(where) < 1 {
+		for _, fieldName := range tableFields {
+			if fieldName == types.SearchRankField {
+				return types.SelectQueryResponseType{}, errors.New(fmt.Sprintf("%v field requires a search where-condition", types.SearchRankField))
+			}
+		}
+		selectQuery, err := helper.ComputeSelectQueryAll(crud.TableName, tableFields)
+		return types.SelectQueryResponseType{SelectQuery: selectQuery}, err
+	}
+	return helper.ComputeSelectQueryByParam(crud.TableName, where, tableFields)
+}
+
+// computeDeleteQuery method computes the delete-query (where-script) by record-id(s) or query-params
+func (crud *Crud) computeDeleteQuery() (types.DeleteQueryResponseType, error) {
+	if len(crud.RecordIds) > 0 {
+		return helper.ComputeDeleteQueryById(crud.TableName, crud.RecordIds)
+	}
+	if len(crud.QueryParams) > 0 {
+		return helper.ComputeDeleteQueryByParam(crud.TableName, crud.QueryParams)
+	}
+	return types.DeleteQueryResponseType{}, errors.New("record-ids or query-params are required for the restore operation")
+}
+
+// Restore method restores the soft-deleted record(s), by record-id(s) or query-params, i.e. unsets the
+// deleted_at and deleted_by fields, subject to the delete task-permission, for the CheckAccess option.
+// The restore is audit-logged (update-task), for the LogDelete option.
+func (crud *Crud) Restore() mcresponse.ResponseMessage {
+	// check task-permission - delete
+	if crud.CheckAccess {
+		accessRes := crud.TaskPermission(tasks.Delete)
+		if accessRes.Code != "success" {
+			return accessRes
+		}
+	}
+	tx, txRes, ok := crud.beginWrite(nil, nil, "updateError", "Error restoring record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	deleteQuery, rQErr := crud.computeDeleteQuery()
+	restoreQuery := types.DeleteQueryResponseType{}
+	returningQuery := ""
+	if rQErr == nil {
+		restoreQuery, rQErr = helper.ComputeRestoreQuery(crud.TableName, deleteQuery)
+	}
+	if rQErr == nil {
+		returningQuery, rQErr = crud.returningQuery()
+	}
+	if rQErr != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing restore-query: %v", rQErr.Error()),
+			Value:   nil,
+		})
+	}
+	restoreCount, restoreRecords, rErr := execReturning(tx, restoreQuery.DeleteQuery, restoreQuery.FieldValues, returningQuery)
+	if rErr == nil {
+		rErr = tx.Commit(context.Background())
+	}
+	if rErr != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error restoring record(s): %v", rErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	// delete cache
+	crud.deleteCache()
+
+	// perform audit-log
+	logMessage := ""
+	if crud.LogDelete {
+		var newLogRecords interface{} = map[string]interface{}{
+			types.DeletedAtField: nil,
+			types.DeletedByField: nil,
+			"record_count":       restoreCount,
+		}
+		if crud.ReturnRecords {
+			newLogRecords = restoreRecords
+		}
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName: crud.TableName,
+			LogRecords: map[string]interface{}{
+				"query_desc":   "restore soft-deleted records",
+				"record_ids":   crud.RecordIds,
+				"query_params": crud.QueryParams,
+			},
+			NewLogRecords: newLogRecords,
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Update, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Record(s) restored successfully | " + logMessage,
+		Value: types.CrudResultType{
+			QueryParam:   crud.QueryParams,
+			RecordIds:    crud.RecordIds,
+			RecordCount:  restoreCount,
+			TableRecords: restoreRecords,
+		},
+	})
+}
+
+// Purge method deletes (permanently) the soft-deleted records, deleted more than the specified number of days ago
+// (all soft-deleted records, for 0 days). The purge is permitted for admin-users only (see DeleteAll), and
+// audit-logged (delete-task), for the LogDelete option.
+func (crud *Crud) Purge(days int) mcresponse.ResponseMessage {
+	// check admin access, i.e. permanent delete of the soft-deleted records
+	if accessRes, ok := crud.checkAdminAccess("purge"); !ok {
+		return accessRes
+	}
+	tx, txRes, ok := crud.beginWrite(nil, nil, "deleteError", "Error purging record(s)")
+	if !ok {
+		return txRes
+	}
+	defer tx.Rollback(context.Background())
+	purgeQuery, pQErr := helper.ComputePurgeQuery(crud.TableName, days)
+	returningQuery := ""
+	if pQErr == nil {
+		returningQuery, pQErr = crud.returningQuery()
+	}
+	if pQErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error computing purge-query: %v", pQErr.Error()),
+			Value:   nil,
+		})
+	}
+	purgeCount, purgeRecords, pErr := execReturning(tx, purgeQuery.DeleteQuery, purgeQuery.FieldValues, returningQuery)
+	if pErr == nil {
+		pErr = tx.Commit(context.Background())
+	}
+	if pErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error purging record(s): %v", pErr.Error()),
+			Value:   nil,
+		})
+	}
+
+	// delete cache
+	crud.deleteCache()
+
+	// perform audit-log
+	logMessage := ""
+	if crud.LogDelete {
+		var logRecords interface{} = map[string]interface{}{
+			"query_desc":   fmt.Sprintf("purge soft-deleted records, older than %v day(s)", days),
+			"record_count": purgeCount,
+		}
+		if crud.ReturnRecords {
+			logRecords = purgeRecords
+		}
+		auditInfo := mcauditlog.PgxAuditLogOptionsType{
+			TableName:  crud.TableName,
+			LogRecords: logRecords,
+		}
+		if logRes, logErr := crud.TransLog.AuditLog(tasks.Delete, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Record(s) purged successfully | " + logMessage,
+		Value: types.CrudResultType{
+			RecordCount:  purgeCount,
+			TableRecords: purgeRecords,
+		},
+	})
+}
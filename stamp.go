@@ -0,0 +1,114 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: actor, time and active stamps, for the create and update operations
+
+package mccrud
+
+import (
+	"context"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mctypes/tasks"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// computeStamps method returns the Model stamps, for the create/update (taskType) transaction: TimeStamp (created_at
+// and updated_at, the transaction CURRENT_TIMESTAMP) and ActorStamp (created_by and updated_by, from the
+// UserInfo.UserId), and the fields removed from the update records (created_*), i.e. not updatable.
+func (crud *Crud) computeStamps(tx pgx.Tx, taskType string) (types.ActionParamType, []string, error) {
+	stamps := types.ActionParamType{}
+	var removeFields []string
+	if crud.Model.TimeStamp {
+		// the transaction start time, i.e. the same stamp-time for all the transaction records
+		var stampTime time.Time
+		if err := tx.QueryRow(context.Background(), "SELECT CURRENT_TIMESTAMP").Scan(&stampTime); err != nil {
+			return nil, nil, err
+		}
+		// updated_at, as the VersionField, is set by the update-script
+		if taskType == tasks.Create || crud.VersionField != "updated_at" {
+			stamps["updated_at"] = stampTime
+		}
+		if taskType == tasks.Create {
+			stamps["created_at"] = stampTime
+		} else {
+			removeFields = append(removeFields, "created_at")
+		}
+	}
+	if crud.Model.ActorStamp && crud.UserInfo.UserId != "" {
+		stamps["updated_by"] = crud.UserInfo.UserId
+		if taskType == tasks.Create {
+			stamps["created_by"] = crud.UserInfo.UserId
+		}
+	}
+	if crud.Model.ActorStamp && taskType != tasks.Create {
+		removeFields = append(removeFields, "created_by")
+	}
+	return stamps, removeFields, nil
+}
+
+// stampRecords method returns the records (copies) and tableFields, stamped by the Model stamps (see computeStamps),
+// in the transaction, and ActiveStamp (is_active, default: true, for create). The created_* fields are removed from
+// the update records. The records and tableFields are returned as-is, if the Model is not specified.
+func (crud *Crud) stampRecords(tx pgx.Tx, recs types.ActionParamsType, tableFields []string, taskType string) (types.ActionParamsType, []string, error) {
+	if crud.Model == nil || !(crud.Model.TimeStamp || crud.Model.ActorStamp || crud.Model.ActiveStamp) {
+		return recs, tableFields, nil
+	}
+	stamps, removeFields, err := crud.computeStamps(tx, taskType)
+	if err != nil {
+		return nil, nil, err
+	}
+	// stamped records
+	var stampedRecs types.ActionParamsType
+	for _, rec := range recs {
+		stampedRec := types.ActionParamType{}
+		for fieldName, fieldValue := range rec {
+			if !helper.ArrayStringContains(removeFields, fieldName) {
+				stampedRec[fieldName] = fieldValue
+			}
+		}
+		for fieldName, fieldValue := range stamps {
+			stampedRec[fieldName] = fieldValue
+		}
+		if _, ok := stampedRec["is_active"]; !ok && crud.Model.ActiveStamp && taskType == tasks.Create {
+			stampedRec["is_active"] = true
+		}
+		stampedRecs = append(stampedRecs, stampedRec)
+	}
+	// stamped tableFields, if specified
+	if len(tableFields) < 1 {
+		return stampedRecs, tableFields, nil
+	}
+	var stampedFields []string
+	for _, fieldName := range tableFields {
+		if !helper.ArrayStringContains(removeFields, fieldName) {
+			stampedFields = append(stampedFields, fieldName)
+		}
+	}
+	for _, fieldName := range []string{"created_at", "updated_at", "created_by", "updated_by"} {
+		if _, ok := stamps[fieldName]; ok && !helper.ArrayStringContains(stampedFields, fieldName) {
+			stampedFields = append(stampedFields, fieldName)
+		}
+	}
+	if crud.Model.ActiveStamp && taskType == tasks.Create && !helper.ArrayStringContains(stampedFields, "is_active") {
+		stampedFields = append(stampedFields, "is_active")
+	}
+	return stampedRecs, stampedFields, nil
+}
+
+// createStamps method returns the create stamps (see computeStamps) of the copy/staged records, i.e. the insert-select
+// from the temp-table (tableFields), including the ActiveStamp (is_active: true), if not loaded. No stamps are
+// returned, if the Model is not specified.
+func (crud *Crud) createStamps(tx pgx.Tx, tableFields []string) (types.ActionParamType, error) {
+	if crud.Model == nil || !(crud.Model.TimeStamp || crud.Model.ActorStamp || crud.Model.ActiveStamp) {
+		return nil, nil
+	}
+	stamps, _, err := crud.computeStamps(tx, tasks.Create)
+	if err != nil {
+		return nil, err
+	}
+	if crud.Model.ActiveStamp && !helper.ArrayStringContains(tableFields, "is_active") {
+		stamps["is_active"] = true
+	}
+	return stamps, nil
+}
@@ -0,0 +1,65 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: extended where-operators, in addition to the mctypes operators
+
+package types
+
+// pattern (like/ilike) operators: the Like/ILike values are patterns (% and _ wildcards), the IStartsWith,
+// IEndsWith and IIncludes values are literal strings (case-insensitive)
+const (
+	Like           = "like"
+	NotLike        = "notlike"
+	ILike          = "ilike"
+	NotILike       = "notilike"
+	IStartsWith    = "istartswith"
+	IEndsWith      = "iendswith"
+	IIncludes      = "iincludes"
+	NotIStartsWith = "notistartswith"
+	NotIEndsWith   = "notiendswith"
+	NotIIncludes   = "notiincludes"
+)
+
+// range and null operators: the Between/NotBetween value is the [low, high] pair, the IsNull value is true (IS NULL)
+// or false (IS NOT NULL)
+const (
+	Between    = "between"
+	NotBetween = "notbetween"
+	IsNull     = "isnull"
+	IsNotNull  = "isnotnull"
+)
+
+// POSIX regular-expression operators
+const (
+	Regex     = "regex"
+	IRegex    = "iregex"
+	NotRegex  = "notregex"
+	NotIRegex = "notiregex"
+)
+
+// array operators: the values are arrays (slices), e.g. ArrayContains: the field-array contains all the value items
+const (
+	ArrayContains    = "arraycontains"
+	ArrayContainedBy = "arraycontainedby"
+	ArrayOverlaps    = "arrayoverlaps"
+)
+
+// jsonb operators: the JsonContains/JsonContainedBy values are json documents (maps, slices or json strings),
+// the JsonHasKey value is a key, the JsonHasAnyKeys/JsonHasAllKeys values are keys (slices) and the
+// JsonPath/JsonPathExists values are jsonpath expressions, e.g. $.price > 10 (predicate) or $.tags[*] (exists)
+const (
+	JsonContains    = "jsoncontains"
+	JsonContainedBy = "jsoncontainedby"
+	JsonHasKey      = "jsonhaskey"
+	JsonHasAnyKeys  = "jsonhasanykeys"
+	JsonHasAllKeys  = "jsonhasallkeys"
+	JsonPath        = "jsonpath"
+	JsonPathExists  = "jsonpathexists"
+)
+
+// full-text search operator: the field-name is the (comma-separated) text field-names, e.g. "title,body", and the
+// value is the web-search query (string) or the query and text-search config, e.g. {"query": "...", "config": "simple"}.
+// The SearchRankField (ts_rank) is a virtual (projectable and sortable) field, for the search where-conditions.
+const (
+	Search          = "search"
+	SearchRankField = "search_rank"
+)
@@ -6,11 +6,13 @@ package types
 
 import (
 	"fmt"
+	"github.com/abbeymart/mccrud/cache"
 	"github.com/abbeymart/mctypes"
 
 	//"github.com/abbeymart/mctypes"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.mongodb.org/mongo-driver/mongo"
+	"time"
 )
 
 type RoleServiceType struct {
@@ -57,17 +59,74 @@ type ExistParamsType []ExistParamType
 type SortParamType map[string]int     // 1 for "asc", -1 for "desc"
 type ProjectParamType map[string]bool // 1 or true for inclusion, 0 or false for exclusion
 
+// SortItemType is the ordered sort/order-by specification for a field, for multiple-fields sorting by priority
+type SortItemType struct {
+	FieldName string `json:"fieldName"`
+	Order     int    `json:"order"` // 1 for "asc", -1 for "desc"
+	Nulls     string `json:"nulls"` // "first" or "last" | default: "" (asc => nulls last, desc => nulls first)
+}
+type SortItemsType []SortItemType
+
+// soft-delete field-names
+const (
+	DeletedAtField = "deleted_at"
+	DeletedByField = "deleted_by"
+)
+
+// aggregate functions
+const (
+	AggregateSum           = "sum"
+	AggregateAvg           = "avg"
+	AggregateMin           = "min"
+	AggregateMax           = "max"
+	AggregateCount         = "count"
+	AggregateCountDistinct = "countdistinct"
+)
+
+// AggregateItemType is the aggregate function, on the field-name, for the Aggregate query result field (alias)
+type AggregateItemType struct {
+	Function  string `json:"function"`  // sum, avg, min, max, count, countDistinct
+	FieldName string `json:"fieldName"` // optional for count, i.e. count(*)
+	Alias     string `json:"alias"`     // result field-name, default: function_fieldName, e.g. sum_amount
+}
+
+// AggregateParamsType is the Aggregate query specification, the Having conditions field-names are the
+// aggregate-items' aliases and/or GroupBy field-names
+type AggregateParamsType struct {
+	Items   []AggregateItemType `json:"items"`
+	GroupBy []string            `json:"groupBy"`
+	Having  QueryParamType      `json:"having"`
+}
+
+// keyset/cursor paging directions
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+// CursorType is the decoded keyset/cursor-paging information, i.e. the sort-fields (including id) and the
+// sort-fields' values of the boundary record (last record for the next-page, first record for the prev-page)
+type CursorType struct {
+	Direction  string        `json:"direction"` // CursorNext or CursorPrev
+	FieldNames []string      `json:"fieldNames"`
+	SortOrders []int         `json:"sortOrders"` // 1 (asc) or -1 (desc), for each of the FieldNames
+	Values     []interface{} `json:"values"`
+}
+
 type QueryItemType struct {
-	GroupItem      map[string]map[string]interface{} `json:"groupItem"`      // key1 => fieldName, key2 => fieldOperator, interface{}=> value(s)
-	GroupItemOrder int                               `json:"groupItemOrder"` // item/field order within the group
-	GroupItemOp    string                            `json:"groupItemOp"`    // group-item relationship to the next item (AND, OR), the last item groupItemOp should be "" or will be ignored
+	GroupItem      map[string]map[string]interface{} `json:"groupItem"`              // key1 => fieldName, key2 => fieldOperator, interface{}=> value(s)
+	Group          *QueryGroupType                   `json:"group,omitempty"`        // nested group (sub-expression), in place of the groupItem, e.g. a OR (b AND c)
+	GroupItemOrder int                               `json:"groupItemOrder"`         // item/field order within the group
+	GroupItemOp    string                            `json:"groupItemOp"`            // group-item relationship to the next item (AND, OR), the last item groupItemOp should be "" or will be ignored
+	GroupItemNot   bool                              `json:"groupItemNot,omitempty"` // negate (NOT) the group-item condition
 }
 
 type QueryGroupType struct {
-	GroupName   string          `json:"groupName"`   // for group-items(fields) categorization
-	GroupItems  []QueryItemType `json:"groupItems"`  // group items to be composed by category
-	GroupOrder  int             `json:"groupOrder"`  // group order
-	GroupLinkOp string          `json:"groupLinkOp"` // group relationship to the next group (AND, OR), the last group groupLinkOp should be "" or will be ignored
+	GroupName   string          `json:"groupName"`          // for group-items(fields) categorization
+	GroupItems  []QueryItemType `json:"groupItems"`         // group items to be composed by category
+	GroupOrder  int             `json:"groupOrder"`         // group order
+	GroupLinkOp string          `json:"groupLinkOp"`        // group relationship to the next group (AND, OR), the last group groupLinkOp should be "" or will be ignored
+	GroupNot    bool            `json:"groupNot,omitempty"` // negate (NOT) the group-items conditions
 }
 
 type QueryParamType []QueryGroupType
@@ -75,25 +134,28 @@ type WhereParamType []QueryGroupType
 
 // CrudParamsType is the struct type for receiving, composing and passing CRUD inputs
 type CrudParamsType struct {
-	AppDb         *pgxpool.Pool        `json:"-"`
-	TableName     string               `json:"-"`
-	UserInfo      mctypes.UserInfoType `json:"userInfo"`
-	ActionParams  ActionParamsType     `json:"actionParams"`
-	ExistParams   ExistParamsType      `json:"existParams"`
-	QueryParams   QueryParamType       `json:"queryParams"`
-	RecordIds     []string             `json:"recordIds"`
-	ProjectParams ProjectParamType     `json:"projectParams"`
-	SortParams    SortParamType        `json:"sortParams"`
-	Token         string               `json:"token"`
-	Skip          int                  `json:"skip"`
-	Limit         int                  `json:"limit"`
-	TaskName      string               `json:"-"`
+	AppDb            *pgxpool.Pool        `json:"-"`
+	TableName        string               `json:"-"`
+	UserInfo         mctypes.UserInfoType `json:"userInfo"`
+	ActionParams     ActionParamsType     `json:"actionParams"`
+	ExistParams      ExistParamsType      `json:"existParams"` // duplicate checks (field-names, and values if not in the records), for create and update
+	QueryParams      QueryParamType       `json:"queryParams"`
+	RecordIds        []string             `json:"recordIds"`
+	ProjectParams    ProjectParamType     `json:"projectParams"`
+	SortParams       SortParamType        `json:"sortParams"`
+	SortItems        SortItemsType        `json:"sortItems"` // ordered alternative to SortParams, takes precedence
+	Token            string               `json:"token"`
+	Skip             int                  `json:"skip"`
+	Limit            int                  `json:"limit"`
+	Cursor           string               `json:"cursor"`           // next/prev-cursor from a previous read, in place of skip
+	IncludeRelations []string             `json:"includeRelations"` // Model.Relations' TargetTable(s), to include/nest in the read records
+	TaskName         string               `json:"-"`
 }
 
 type CrudOptionsType struct {
-	ParentTables          []string
-	ChildTables           []string
-	RecursiveDelete       bool
+	ParentTables          []string // tables referenced by the table, never cascaded into by the RecursiveDelete
+	ChildTables           []string // delete: tables referencing the table, checked for sub-items (referencing records)
+	RecursiveDelete       bool     // delete: cascade through the ChildTables (and their child-tables), in place of the sub-items error
 	CheckAccess           bool
 	AccessDb              *pgxpool.Pool
 	AuditDb               *pgxpool.Pool
@@ -106,6 +168,21 @@ type CrudOptionsType struct {
 	VerifyTable           string
 	UserProfileTable      string
 	MaxQueryLimit         int
+	CursorPaging          bool               // keyset/cursor paging (next/prev-cursor), in place of skip/offset paging
+	FetchSize             int                // GetStream: number of records fetched per server-side cursor round-trip
+	BatchSize             int                // CreateBatch/Update: number of statements queued per (pgx.Batch) round-trip
+	Model                 *mctypes.ModelType // table model, for the relations (IncludeRelations) and the create/update (actor, time and active) stamps
+	Upsert                bool               // Save/SaveRecord: insert or update (on conflict) the records, in place of create or update
+	ConflictFields        []string           // upsert conflict target field-names, default: id
+	UpsertFields          []string           // upsert (on conflict) update field-names, default: the record fields, except the ConflictFields
+	UpsertDoNothing       bool               // upsert: skip (do nothing), in place of update, on conflict
+	VersionField          string             // Update: optimistic concurrency control version field-name, e.g. version or updated_at
+	ReturnRecords         bool               // create, update and delete: return the resulting records (ProjectParams fields or all fields)
+	SoftDelete            bool               // DeleteById/DeleteByParam/DeleteAll: set the deleted_at/deleted_by fields, in place of delete; update: skip the soft-deleted records
+	IncludeDeleted        bool               // read (get, stream, count, exists and aggregate): include the soft-deleted records
+	DeleteAllMaxCount     int                // DeleteAll: maximum number of records permitted to be deleted, default: 10000
+	DeleteAllBackupTable  string             // DeleteAll: table to archive (copy) the records to, prior to the delete, if specified
+	ValidateModel         bool               // SaveRecord, GetRecord(s) and DeleteRecord: validate the params against the Model fields
 	LogCrud               bool
 	LogCreate             bool
 	LogUpdate             bool
@@ -115,7 +192,10 @@ type CrudOptionsType struct {
 	LogLogout             bool
 	UnAuthorizedMessage   string
 	RecExistMessage       string
-	CacheExpire           int
+	Cache                 cache.Cache              // read-through query-result cache (opt-in), e.g. cache.NewLRUCache, no caching if nil
+	CacheTTL              time.Duration            // cache entry ttl, default: 5 minutes, no caching if negative
+	TableCacheTTL         map[string]time.Duration // cache entry ttl, by table-name, in place of the CacheTTL
+	CacheNamespace        string                   // cache-key namespace, i.e. the database of the records, default: the AppDb host, port and database hash
 	LoginTimeout          int
 	UsernameExistsMessage string
 	EmailExistsMessage    string
@@ -289,6 +369,12 @@ type CreateQueryResponseType struct {
 	FieldValues [][]interface{}
 }
 
+type UpsertQueryResponseType struct {
+	UpsertQuery string
+	FieldNames  []string
+	FieldValues []interface{}
+}
+
 type UpdateQueryResponseType struct {
 	UpdateQuery string
 	WhereQuery  string
@@ -386,10 +472,70 @@ func (err ErrorType) Error() string {
 }
 
 type CrudResultType struct {
-	QueryParam   QueryParamType `json:"query_param"`
-	RecordIds    []string               `json:"record_ids"`
-	RecordCount  int                    `json:"record_count"`
-	TableRecords []interface{}          `json:"table_records"`
+	QueryParam    QueryParamType     `json:"query_param"`
+	RecordIds     []string           `json:"record_ids"`
+	RecordCount   int                `json:"record_count"`
+	TableRecords  []interface{}      `json:"table_records"`
+	NextCursor    string             `json:"next_cursor"`    // cursor-paging: cursor for the next-page, if any
+	PrevCursor    string             `json:"prev_cursor"`    // cursor-paging: cursor for the previous-page, if any
+	UpsertResults []UpsertResultType `json:"upsert_results"` // upsert: the result for each of the records
+}
+
+// upsert record actions
+const (
+	UpsertInserted = "inserted"
+	UpsertUpdated  = "updated"
+	UpsertSkipped  = "skipped"
+)
+
+// UpsertResultType is the upsert result for the record (index in the action-params): inserted, updated or skipped
+type UpsertResultType struct {
+	RecordIndex int    `json:"record_index"`
+	RecordId    string `json:"record_id"`
+	Action      string `json:"action"`
+}
+
+// RecExistType is the duplicate (ExistParams) check result for the record (index in the action-params):
+// the existParam field-names that collided with an existing record or a preceding record in the action-params
+type RecExistType struct {
+	RecordIndex int      `json:"record_index"`
+	RecordId    string   `json:"record_id"`
+	FieldNames  []string `json:"field_names"`
+}
+
+// TableReferenceType is the (single-column foreign-key) reference of the child-table field to the parent-table field
+type TableReferenceType struct {
+	TableName   string `json:"table_name"`
+	FieldName   string `json:"field_name"`
+	ParentTable string `json:"parent_table"`
+	ParentField string `json:"parent_field"`
+}
+
+// RecordSourceType is the (streaming) record-source, e.g. a file or query reader, for the bulk (COPY) create
+type RecordSourceType interface {
+	Next() bool                       // advances to the next record, false if no more records or on error
+	Record() (ActionParamType, error) // the current record
+	Err() error                       // the error, if any, that stopped the records iteration
+}
+
+// StageParamsType is the CreateStaged (temp-table bulk-load) specification
+type StageParamsType struct {
+	TableFields    []string // copy/insert field-names, default: from the first record
+	ConflictFields []string // optional on-conflict target field-names
+	UpdateFields   []string // on-conflict update field-names (DO UPDATE), DO NOTHING, if not specified
+}
+
+// StreamRecordType is the GetStream channel value: a record (json-value-format) or the stream error
+type StreamRecordType struct {
+	Record map[string]interface{}
+	Err    error
+}
+
+// RecordStreamType is the GetStream response Value: the Records channel, closed at the end of the stream, and the Stop
+// function, that stops the stream early and releases the stream transaction/connection, i.e. for an abandoned stream
+type RecordStreamType struct {
+	Records <-chan StreamRecordType
+	Stop    func()
 }
 
 type LogRecordsType struct {
@@ -398,3 +544,16 @@ type LogRecordsType struct {
 	QueryParam   mctypes.WhereParamType `json:"query_param"`
 	RecordIds    []string               `json:"record_ids"`
 }
+
+// TableOptionsType is the table (create, alter and sync) options
+type TableOptionsType struct {
+	DryRun      bool // SyncTable: compute the sync plan, without applying it
+	Destructive bool // SyncTable: apply the destructive (non-additive) changes, e.g. drop/re-create the table (model.AlterSyncTable false) and drop columns
+	SoftDelete  bool // the soft-delete columns (deleted_at, deleted_by), for the soft-deletable model (crud SoftDelete option)
+}
+
+// SyncTableResultType is the table-sync plan/result
+type SyncTableResultType struct {
+	Scripts        []string `json:"scripts"`         // applied (or planned, for the DryRun) scripts, in order
+	SkippedScripts []string `json:"skipped_scripts"` // destructive (non-additive) scripts, reported and not applied, without the Destructive option
+}
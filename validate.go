@@ -0,0 +1,95 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-18 | @Updated: 2026-10-18
+// @Company: mConnect.biz | @License: MIT
+// @Description: validate the crud params against the model
+
+package mccrud
+
+import (
+	"context"
+	"fmt"
+	"github.com/abbeymart/mccrud/helper"
+	"github.com/abbeymart/mccrud/types"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctypes/datatypes"
+	"github.com/jackc/pgx/v4"
+)
+
+// paramsError is the params validation error, i.e. the validation errors of the paramsError response
+type paramsError struct {
+	validateRes types.ValidateResponseType
+}
+
+func (err paramsError) Error() string {
+	return "Invalid params: the field-names, operators and values must be valid for the model"
+}
+
+// modelFields method returns the model field-kinds (see helper.ComputeModelFields), including the soft-delete fields
+// (deleted_at and deleted_by), for the SoftDelete option
+func (crud *Crud) modelFields() map[string]string {
+	extraFields := map[string]string{}
+	if crud.SoftDelete {
+		extraFields[types.DeletedAtField] = datatypes.DateTime
+		extraFields[types.DeletedByField] = datatypes.String
+	}
+	return helper.ComputeModelFields(*crud.Model, extraFields)
+}
+
+// ValidateParams method validates the query, sort, project and action params against the Model fields, i.e. unknown
+// field-names and operator/value type mismatches. The soft-delete fields (deleted_at and deleted_by) are permitted,
+// for the SoftDelete option. The params are valid (Ok), if the Model is not specified.
+func (crud *Crud) ValidateParams() types.ValidateResponseType {
+	if crud.Model == nil {
+		return types.ValidateResponseType{Ok: true, Errors: types.MessageObject{}}
+	}
+	return helper.ValidateParams(crud.CrudParamsType, crud.modelFields())
+}
+
+// validateParams method validates the params, i.e. the query, sort, project and action params that reach the SQL
+// scripts (e.g. the read where-params or the call-time records, in place of the crud params), and the tableFields
+// against the Model fields, for the ValidateModel option, and returns the paramsError, if invalid
+func (crud *Crud) validateParams(params types.CrudParamsType, tableFields []string) error {
+	if !crud.ValidateModel || crud.Model == nil {
+		return nil
+	}
+	modelFields := crud.modelFields()
+	validateRes := helper.ValidateParams(params, modelFields)
+	for errorKey, message := range helper.ValidateTableFields(tableFields, modelFields).Errors {
+		validateRes.Errors[errorKey] = message
+	}
+	validateRes.Ok = len(validateRes.Errors) < 1
+	if validateRes.Ok {
+		return nil
+	}
+	return paramsError{validateRes: validateRes}
+}
+
+// errorResponse function returns the paramsError response (Value: validation errors), for the params validation
+// error, or the errorCode response, with the message and the error, otherwise
+func errorResponse(err error, errorCode string, message string) mcresponse.ResponseMessage {
+	if pErr, ok := err.(paramsError); ok {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: pErr.Error(),
+			Value:   pErr.validateRes,
+		})
+	}
+	return mcresponse.GetResMessage(errorCode, mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v: %v", message, err.Error()),
+		Value:   nil,
+	})
+}
+
+// beginWrite method is the write pre-flight of the create, update and delete methods: validates the params, with the
+// call-time records (writeRecs), and the tableFields (see validateParams), then begins the write transaction.
+// The paramsError response, or the errorCode response (transaction error), is returned with false, otherwise.
+func (crud *Crud) beginWrite(writeRecs types.ActionParamsType, tableFields []string, errorCode string, message string) (pgx.Tx, mcresponse.ResponseMessage, bool) {
+	params := crud.CrudParamsType
+	params.ActionParams = writeRecs
+	if err := crud.validateParams(params, tableFields); err != nil {
+		return nil, errorResponse(err, errorCode, message), false
+	}
+	tx, txErr := crud.AppDb.Begin(context.Background())
+	if txErr != nil {
+		return nil, errorResponse(txErr, errorCode, message), false
+	}
+	return tx, mcresponse.ResponseMessage{}, true
+}